@@ -0,0 +1,63 @@
+// Command importfoods loads a food composition CSV into the foods table. Rows
+// are upserted by source and id, so re-running an import updates it in place.
+//
+//	go run ./cmd/importfoods -file data/foods.csv -source balancewise
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/priyanshujain/balancewise/server/internal/config"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/foodcsv"
+	dietpostgres "github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/postgres"
+)
+
+func main() {
+	path := flag.String("file", "data/foods.csv", "food composition CSV to import")
+	source := flag.String("source", "balancewise", "name of the dataset the CSV comes from, e.g. usda or ifct")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	})))
+
+	dbConfig, err := config.LoadDatabaseFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Failed to open food file: %v", err)
+	}
+	defer file.Close()
+
+	foods, err := foodcsv.Parse(file, *source)
+	if err != nil {
+		log.Fatalf("Failed to parse food file: %v", err)
+	}
+
+	ctx := context.Background()
+
+	db, err := dbConfig.Init(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dietService := dietsvc.NewService(dietsvc.ServiceConfig{
+		FoodRepository: dietpostgres.NewFoodRepository(db),
+	})
+
+	imported, err := dietService.ImportFoods(ctx, foods)
+	if err != nil {
+		log.Fatalf("Failed to import foods after %d rows: %v", imported, err)
+	}
+
+	slog.Info("foods imported", "count", imported, "source", *source, "file", *path)
+}
@@ -0,0 +1,87 @@
+// Command importproducts loads an Open Food Facts CSV export into the products
+// table used for barcode lookups. Rows are upserted by barcode, so re-running
+// an import with a newer export updates the catalog in place.
+//
+//	go run ./cmd/importproducts -file en.openfoodfacts.org.products.csv
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/priyanshujain/balancewise/server/internal/config"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/openfoodfacts"
+	dietpostgres "github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/postgres"
+)
+
+// progressInterval is how many imported products pass between progress logs
+const progressInterval = 10000
+
+func main() {
+	path := flag.String("file", "", "Open Food Facts CSV export to import")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	})))
+
+	if *path == "" {
+		log.Fatalf("-file is required")
+	}
+
+	dbConfig, err := config.LoadDatabaseFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Failed to open product file: %v", err)
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+
+	db, err := dbConfig.Init(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dietService := dietsvc.NewService(dietsvc.ServiceConfig{
+		ProductRepository: dietpostgres.NewProductRepository(db),
+	})
+
+	var imported, invalid int
+	incomplete, err := openfoodfacts.Read(file, func(product domain.Product) error {
+		if err := dietService.ImportProduct(ctx, product); err != nil {
+			if errors.Is(err, dietsvc.ErrInvalidProduct) {
+				invalid++
+				return nil
+			}
+			return err
+		}
+
+		imported++
+		if imported%progressInterval == 0 {
+			slog.Info("importing products", "imported", imported)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to import products after %d rows: %v", imported, err)
+	}
+
+	slog.Info("products imported",
+		"imported", imported,
+		"skipped_incomplete", incomplete,
+		"skipped_invalid", invalid,
+		"file", *path,
+	)
+}
@@ -21,7 +21,14 @@ import (
 	"github.com/priyanshujain/balancewise/server/internal/config"
 	"github.com/priyanshujain/balancewise/server/internal/dietapi"
 	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	dietdomain "github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/fake"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/imageproc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/memory"
 	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/openai"
+	dietpostgres "github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/postgres"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/resilience"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/webhook"
 	"github.com/priyanshujain/balancewise/server/internal/generic/httplog"
 	"github.com/priyanshujain/balancewise/server/internal/jwt"
 )
@@ -84,15 +91,73 @@ func main() {
 		JWTService:      jwtService,
 	})
 
-	// Initialize OpenAI vision client
-	visionClient := openai.NewVisionClient(cfg.OpenAIAPIKey)
+	// Initialize food analyzer
+	analyzer, err := newFoodAnalyzer(cfg.DietConfig, cfg.OpenAIAPIKey)
+	if err != nil {
+		log.Fatalf("Failed to initialize food analyzer: %v", err)
+	}
+	resilientAnalyzer := resilience.NewAnalyzer(analyzer, resilience.Config{
+		AttemptTimeout:   cfg.DietConfig.AnalyzerTimeout,
+		MaxAttempts:      cfg.DietConfig.AnalyzerMaxAttempts,
+		BaseBackoff:      500 * time.Millisecond,
+		MaxBackoff:       8 * time.Second,
+		BreakerThreshold: cfg.DietConfig.BreakerThreshold,
+		BreakerCooldown:  cfg.DietConfig.BreakerCooldown,
+		Retryable:        openai.IsRetryable,
+	})
+
+	// Initialize meal planner
+	planner, err := newMealPlanner(cfg.DietConfig, cfg.OpenAIAPIKey)
+	if err != nil {
+		log.Fatalf("Failed to initialize meal planner: %v", err)
+	}
+
+	// Initialize analysis cache
+	var analysisCache dietdomain.AnalysisCache
+	switch cfg.DietConfig.Cache {
+	case config.CachePostgres:
+		analysisCache = dietpostgres.NewAnalysisCache(authDB.DB())
+	case config.CacheMemory:
+		analysisCache = memory.NewAnalysisCache()
+	}
 
 	// Initialize diet service
-	dietService := dietsvc.NewService(visionClient)
+	dietService := dietsvc.NewService(dietsvc.ServiceConfig{
+		Analyzer: resilientAnalyzer,
+		ImageProcessor: imageproc.NewProcessor(imageproc.Config{
+			MaxDimension: cfg.DietConfig.ImageMaxDimension,
+			JPEGQuality:  cfg.DietConfig.ImageJPEGQuality,
+		}),
+		Cache:              analysisCache,
+		CacheTTL:           cfg.DietConfig.CacheTTL,
+		EntryRepository:    dietpostgres.NewEntryRepository(authDB.DB()),
+		SettingsRepository: dietpostgres.NewSettingsRepository(authDB.DB()),
+		FoodRepository:     dietpostgres.NewFoodRepository(authDB.DB()),
+		ProductRepository:  dietpostgres.NewProductRepository(authDB.DB()),
+		JobRepository:      dietpostgres.NewJobRepository(authDB.DB()),
+		JobNotifier: webhook.NewSender(webhook.Config{
+			Secret:       cfg.DietConfig.WebhookSecret,
+			AllowPrivate: cfg.DietConfig.WebhookAllowPrivate,
+		}),
+		JobRetention:          cfg.DietConfig.JobRetention,
+		JobTimeout:            resilientAnalyzer.Budget() + 30*time.Second,
+		BatchConcurrency:      cfg.DietConfig.BatchConcurrency,
+		UsageRepository:       dietpostgres.NewUsageRepository(authDB.DB()),
+		AnalysisRepository:    dietpostgres.NewAnalysisRepository(authDB.DB()),
+		RestrictionRepository: dietpostgres.NewRestrictionRepository(authDB.DB()),
+		RecipeRepository:      dietpostgres.NewRecipeRepository(authDB.DB()),
+		FavoriteRepository:    dietpostgres.NewFavoriteRepository(authDB.DB()),
+		MealPlanner:           resilience.NewPlanner(planner, resilientAnalyzer, cfg.DietConfig.PlannerTimeout),
+		MealPlanRepository:    dietpostgres.NewMealPlanRepository(authDB.DB()),
+		Quota: dietsvc.Quota{
+			Daily:   cfg.DietConfig.QuotaDaily,
+			Monthly: cfg.DietConfig.QuotaMonthly,
+		},
+	})
 
 	// Initialize HTTP handlers
-	authHandler := authapi.NewHandler(authService)
-	dietHandler := dietapi.NewHandler(dietService)
+	authHandler := authapi.NewHandler(authService, analyzerHealthCheck(resilientAnalyzer))
+	dietHandler := dietapi.NewHandler(dietService, authService, cfg.DietConfig.AdminEmails)
 
 	// Create main mux and mount handlers
 	mux := http.NewServeMux()
@@ -127,6 +192,12 @@ func main() {
 		return nil
 	})
 
+	// Start analysis job workers
+	g.Go(func() error {
+		slog.Info("analysis job workers started", "workers", cfg.DietConfig.JobWorkers)
+		return dietService.RunJobWorkers(gCtx, cfg.DietConfig.JobWorkers)
+	})
+
 	// Start cleanup goroutine
 	g.Go(func() error {
 		ticker := time.NewTicker(5 * time.Minute)
@@ -141,6 +212,9 @@ func main() {
 				if err := authService.CleanupExpired(gCtx); err != nil {
 					slog.Error("cleanup error", "error", err)
 				}
+				if err := dietService.CleanupExpired(gCtx); err != nil {
+					slog.Error("diet cleanup error", "error", err)
+				}
 			}
 		}
 	})
@@ -174,6 +248,85 @@ func main() {
 	slog.Info("server stopped gracefully")
 }
 
+// newFoodAnalyzer selects the food analyzer implementation from config
+func newFoodAnalyzer(cfg config.DietConfig, openAIAPIKey string) (dietdomain.FoodAnalyzer, error) {
+	switch cfg.Analyzer {
+	case config.AnalyzerFake:
+		var fixtures map[string]fake.Fixture
+		if cfg.FixturesPath != "" {
+			var err error
+			fixtures, err = fake.LoadFixtures(cfg.FixturesPath)
+			if err != nil {
+				return nil, err
+			}
+		}
+		slog.Info("using fake food analyzer", "fixtures", len(fixtures))
+		return fake.NewFixtureAnalyzer(fixtures), nil
+	default:
+		client, err := openai.NewVisionClient(openai.Config{
+			APIKey:        openAIAPIKey,
+			Model:         cfg.Model,
+			PromptVersion: cfg.PromptVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		provenance := client.Provenance()
+		slog.Info("using openai food analyzer", "model", provenance.Model, "prompt_version", provenance.PromptVersion)
+		return client, nil
+	}
+}
+
+// newMealPlanner selects the meal planner implementation from config,
+// following the analyzer
+func newMealPlanner(cfg config.DietConfig, openAIAPIKey string) (dietdomain.MealPlanner, error) {
+	switch cfg.Analyzer {
+	case config.AnalyzerFake:
+		slog.Info("using fake meal planner")
+		return fake.NewPlanner(), nil
+	default:
+		client, err := openai.NewPlannerClient(openai.Config{
+			APIKey:            openAIAPIKey,
+			Model:             cfg.Model,
+			PlanPromptVersion: cfg.PlanPromptVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		provenance := client.Provenance()
+		slog.Info("using openai meal planner", "model", provenance.Model, "prompt_version", provenance.PromptVersion)
+		return client, nil
+	}
+}
+
+// analyzerHealthCheck reports the analysis provider's circuit breaker
+func analyzerHealthCheck(analyzer *resilience.Analyzer) authapi.HealthCheck {
+	return authapi.HealthCheck{
+		Name: "analyzer",
+		Check: func() authapi.ComponentHealth {
+			breaker := analyzer.BreakerStatus()
+
+			status := "ok"
+			switch breaker.State {
+			case resilience.StateOpen:
+				status = "unavailable"
+			case resilience.StateHalfOpen:
+				status = "degraded"
+			}
+
+			details := map[string]any{
+				"circuit":              breaker.State,
+				"consecutive_failures": breaker.ConsecutiveFailures,
+			}
+			if breaker.RetryAt != nil {
+				details["retry_at"] = breaker.RetryAt.UTC().Format(time.RFC3339)
+			}
+
+			return authapi.ComponentHealth{Status: status, Details: details}
+		},
+	}
+}
+
 // recoveryMiddleware recovers from panics and logs them
 func recoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -14,7 +14,14 @@ import (
 
 type httpHandler struct {
 	http.ServeMux
-	svc *authsvc.Service
+	svc          *authsvc.Service
+	healthChecks []HealthCheck
+}
+
+// HealthCheck reports the health of a dependency in the /health response
+type HealthCheck struct {
+	Name  string
+	Check func() ComponentHealth
 }
 
 type InitiateResponse struct {
@@ -46,7 +53,15 @@ type VerifyResponse struct {
 }
 
 type HealthResponse struct {
-	Status string `json:"status"`
+	Status     string                     `json:"status"`
+	Components map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+// ComponentHealth is one dependency's status, "ok" when healthy, with
+// optional component-specific details
+type ComponentHealth struct {
+	Status  string `json:"status"`
+	Details any    `json:"details,omitempty"`
 }
 
 type GoogleTokenResponse struct {
@@ -54,9 +69,10 @@ type GoogleTokenResponse struct {
 	ExpiresAt   string `json:"expires_at"`
 }
 
-func NewHandler(svc *authsvc.Service) http.Handler {
+func NewHandler(svc *authsvc.Service, healthChecks ...HealthCheck) http.Handler {
 	h := &httpHandler{
-		svc: svc,
+		svc:          svc,
+		healthChecks: healthChecks,
 	}
 	h.init()
 	return h
@@ -441,11 +457,24 @@ func (h *httpHandler) handleGetGoogleToken(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleHealth reports "degraded" when any dependency is unhealthy. It still
+// returns 200, since the server itself is up and can serve other requests.
 func (h *httpHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
 		Status: "ok",
 	}
 
+	if len(h.healthChecks) > 0 {
+		response.Components = make(map[string]ComponentHealth, len(h.healthChecks))
+		for _, check := range h.healthChecks {
+			component := check.Check()
+			if component.Status != "ok" {
+				response.Status = "degraded"
+			}
+			response.Components[check.Name] = component
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
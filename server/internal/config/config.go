@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/priyanshujain/balancewise/server/internal/generic/postgresconfig"
 )
@@ -17,6 +18,7 @@ type Config struct {
 	OpenAIAPIKey string
 	Database     postgresconfig.Config
 	GoogleConfig GoogleConfig
+	DietConfig   DietConfig
 }
 
 type GoogleConfig struct {
@@ -24,6 +26,56 @@ type GoogleConfig struct {
 	ClientSecret string
 }
 
+const (
+	AnalyzerOpenAI = "openai"
+	AnalyzerFake   = "fake"
+
+	CachePostgres = "postgres"
+	CacheMemory   = "memory"
+	CacheNone     = "none"
+)
+
+type DietConfig struct {
+	Analyzer     string
+	FixturesPath string
+	// Model and PromptVersion configure the openai analyzer; empty values use
+	// its defaults
+	Model             string
+	PromptVersion     string
+	ImageMaxDimension int
+	ImageJPEGQuality  int
+	Cache             string
+	CacheTTL          time.Duration
+	// AnalyzerTimeout bounds each provider call; failed calls are retried up
+	// to AnalyzerMaxAttempts in total
+	AnalyzerTimeout     time.Duration
+	AnalyzerMaxAttempts int
+	// PlannerTimeout bounds each meal plan call, which generates far more
+	// than an analysis
+	PlannerTimeout time.Duration
+	// PlanPromptVersion selects the meal plan prompt; empty uses the default
+	PlanPromptVersion string
+	// BreakerThreshold consecutive provider failures stop analysis calls for
+	// BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// BatchConcurrency limits concurrent analyses within one batch request
+	BatchConcurrency int
+	// QuotaDaily and QuotaMonthly limit uncached analyses per user; 0 is
+	// unlimited
+	QuotaDaily   int
+	QuotaMonthly int
+	// AdminEmails may use admin endpoints such as the accuracy report
+	AdminEmails []string
+	// JobWorkers is the number of goroutines processing async analysis jobs
+	JobWorkers   int
+	JobRetention time.Duration
+	// WebhookSecret signs job callbacks with HMAC-SHA256 when set
+	WebhookSecret string
+	// WebhookAllowPrivate permits callbacks to private network addresses
+	WebhookAllowPrivate bool
+}
+
 // LoadFromEnv loads configuration from environment variables and .env file
 func LoadFromEnv() (*Config, error) {
 	// Load .env file if it exists
@@ -38,17 +90,35 @@ func LoadFromEnv() (*Config, error) {
 		JWTSecret:    getEnv("JWT_SECRET", ""),
 		HTTPLog:      getEnv("HTTP_LOG", "true") == "true",
 		OpenAIAPIKey: getEnv("OPENAI_API_KEY", ""),
-		Database: postgresconfig.Config{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnvInt("DB_PORT", 5432),
-			DBName:   getEnv("DB_NAME", "balancewise"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", ""),
-		},
+		Database:     databaseFromEnv(),
 		GoogleConfig: GoogleConfig{
 			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		},
+		DietConfig: DietConfig{
+			Analyzer:            getEnv("DIET_ANALYZER", AnalyzerOpenAI),
+			FixturesPath:        getEnv("DIET_FIXTURES_PATH", ""),
+			Model:               getEnv("DIET_MODEL", ""),
+			PromptVersion:       getEnv("DIET_PROMPT_VERSION", ""),
+			ImageMaxDimension:   getEnvInt("DIET_IMAGE_MAX_DIMENSION", 1024),
+			ImageJPEGQuality:    getEnvInt("DIET_IMAGE_JPEG_QUALITY", 85),
+			Cache:               getEnv("DIET_CACHE", CachePostgres),
+			CacheTTL:            getEnvDuration("DIET_CACHE_TTL", 7*24*time.Hour),
+			AnalyzerTimeout:     getEnvDuration("DIET_ANALYZER_TIMEOUT", 20*time.Second),
+			AnalyzerMaxAttempts: getEnvInt("DIET_ANALYZER_MAX_ATTEMPTS", 3),
+			PlannerTimeout:      getEnvDuration("DIET_PLANNER_TIMEOUT", 2*time.Minute),
+			PlanPromptVersion:   getEnv("DIET_PLAN_PROMPT_VERSION", ""),
+			BreakerThreshold:    getEnvInt("DIET_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:     getEnvDuration("DIET_BREAKER_COOLDOWN", 30*time.Second),
+			BatchConcurrency:    getEnvInt("DIET_BATCH_CONCURRENCY", 4),
+			QuotaDaily:          getEnvInt("DIET_QUOTA_DAILY", 50),
+			QuotaMonthly:        getEnvInt("DIET_QUOTA_MONTHLY", 1000),
+			AdminEmails:         splitList(getEnv("DIET_ADMIN_EMAILS", "")),
+			JobWorkers:          getEnvInt("DIET_JOB_WORKERS", 4),
+			JobRetention:        getEnvDuration("DIET_JOB_RETENTION", 7*24*time.Hour),
+			WebhookSecret:       getEnv("DIET_WEBHOOK_SECRET", ""),
+			WebhookAllowPrivate: getEnv("DIET_WEBHOOK_ALLOW_PRIVATE", "false") == "true",
+		},
 	}
 
 	// Validate required fields
@@ -64,13 +134,65 @@ func LoadFromEnv() (*Config, error) {
 	if cfg.Database.Password == "" {
 		return nil, fmt.Errorf("DB_PASSWORD is required")
 	}
-	if cfg.OpenAIAPIKey == "" {
-		return nil, fmt.Errorf("OPENAI_API_KEY is required")
+	switch cfg.DietConfig.Analyzer {
+	case AnalyzerOpenAI:
+		if cfg.OpenAIAPIKey == "" {
+			return nil, fmt.Errorf("OPENAI_API_KEY is required")
+		}
+	case AnalyzerFake:
+	default:
+		return nil, fmt.Errorf("DIET_ANALYZER must be %q or %q", AnalyzerOpenAI, AnalyzerFake)
+	}
+
+	switch cfg.DietConfig.Cache {
+	case CachePostgres, CacheMemory, CacheNone:
+	default:
+		return nil, fmt.Errorf("DIET_CACHE must be %q, %q or %q", CachePostgres, CacheMemory, CacheNone)
+	}
+	if cfg.DietConfig.AnalyzerMaxAttempts < 1 {
+		return nil, fmt.Errorf("DIET_ANALYZER_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.DietConfig.BreakerThreshold < 1 {
+		return nil, fmt.Errorf("DIET_BREAKER_THRESHOLD must be at least 1")
+	}
+	if cfg.DietConfig.BatchConcurrency < 1 {
+		return nil, fmt.Errorf("DIET_BATCH_CONCURRENCY must be at least 1")
+	}
+	if cfg.DietConfig.QuotaDaily < 0 || cfg.DietConfig.QuotaMonthly < 0 {
+		return nil, fmt.Errorf("DIET_QUOTA_DAILY and DIET_QUOTA_MONTHLY must not be negative")
+	}
+	if cfg.DietConfig.JobWorkers < 1 {
+		return nil, fmt.Errorf("DIET_JOB_WORKERS must be at least 1")
 	}
 
 	return cfg, nil
 }
 
+// LoadDatabaseFromEnv loads only the database configuration, for tools that
+// connect to the database without running the server
+func LoadDatabaseFromEnv() (postgresconfig.Config, error) {
+	if err := loadEnvFile(".env"); err != nil {
+		fmt.Printf("Warning: .env file not found, using environment variables only\n")
+	}
+
+	db := databaseFromEnv()
+	if db.Password == "" {
+		return db, fmt.Errorf("DB_PASSWORD is required")
+	}
+
+	return db, nil
+}
+
+func databaseFromEnv() postgresconfig.Config {
+	return postgresconfig.Config{
+		Host:     getEnv("DB_HOST", "localhost"),
+		Port:     getEnvInt("DB_PORT", 5432),
+		DBName:   getEnv("DB_NAME", "balancewise"),
+		User:     getEnv("DB_USER", "postgres"),
+		Password: getEnv("DB_PASSWORD", ""),
+	}
+}
+
 // loadEnvFile loads environment variables from a .env file
 func loadEnvFile(filename string) error {
 	file, err := os.Open(filename)
@@ -116,6 +238,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		var intValue int
@@ -125,3 +258,12 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,15 @@
+package dietapi
+
+import (
+	"net/http"
+)
+
+func (h *httpHandler) handleBarcode(w http.ResponseWriter, r *http.Request) {
+	analysis, err := h.svc.LookupBarcode(r.Context(), r.PathValue("ean"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAnalyzeResponse(analysis))
+}
@@ -0,0 +1,120 @@
+package dietapi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
+
+const (
+	// maxBatchBodySize allows every image at the 5MB limit plus form fields
+	maxBatchBodySize = dietsvc.MaxBatchImages*(5<<20) + 1<<20
+
+	// batchReadTimeout replaces the server's read timeout for batch
+	// requests, whose bodies carry several images
+	batchReadTimeout = 2 * time.Minute
+
+	// batchWriteTimeout replaces the server's write timeout for batch
+	// requests, which wait on several analyses
+	batchWriteTimeout = 3 * time.Minute
+)
+
+type BatchAnalyzeResponse struct {
+	Results []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult holds either the analysis or the error for the image at
+// Index in the request
+type BatchItemResult struct {
+	Index    int               `json:"index"`
+	Filename string            `json:"filename,omitempty"`
+	Result   *AnalyzeResponse  `json:"result,omitempty"`
+	Error    *httperrors.Error `json:"error,omitempty"`
+}
+
+func (h *httpHandler) handleAnalyzeBatch(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Now().Add(batchReadTimeout)); err != nil {
+		slog.Warn("failed to extend read deadline for batch analysis", "error", err)
+	}
+	if err := rc.SetWriteDeadline(time.Now().Add(batchWriteTimeout)); err != nil {
+		slog.Warn("failed to extend write deadline for batch analysis", "error", err)
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchBodySize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, errFormParse)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	hints, err := hintsFromForm(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	bypassCache := bypassCacheRequested(r)
+
+	files := r.MultipartForm.File["images"]
+	if len(files) > dietsvc.MaxBatchImages {
+		writeError(w, domain.ErrBatchTooLarge)
+		return
+	}
+
+	images := make([]dietsvc.AnalyzeFoodOptions, 0, len(files))
+	for _, fh := range files {
+		file, err := fh.Open()
+		if err != nil {
+			writeError(w, errImageRead)
+			return
+		}
+		imageData, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			writeError(w, errImageRead)
+			return
+		}
+
+		images = append(images, dietsvc.AnalyzeFoodOptions{
+			UserID:      userID,
+			ImageData:   imageData,
+			Hints:       hints,
+			BypassCache: bypassCache,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), batchWriteTimeout-responseMargin)
+	defer cancel()
+	results, err := h.svc.AnalyzeFoodBatch(ctx, images)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := BatchAnalyzeResponse{Results: make([]BatchItemResult, len(results))}
+	failed := 0
+	for i, result := range results {
+		item := BatchItemResult{Index: i, Filename: files[i].Filename}
+		if result.Err != nil {
+			failed++
+			httpErr := httperrors.From(result.Err)
+			item.Error = &httpErr
+		} else {
+			analysis := toAnalyzeResponse(result.Result.Analysis)
+			analysis.Cached = result.Result.Cached
+			item.Result = &analysis
+		}
+		response.Results[i] = item
+	}
+
+	writeJSON(w, http.StatusOK, response)
+
+	slog.Info("analyzed food image batch", "images", len(results), "failed", failed)
+}
@@ -0,0 +1,164 @@
+package dietapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// CorrectionRequest holds the user's corrected values; omitted values were
+// not corrected
+type CorrectionRequest struct {
+	Calories *float64 `json:"calories"`
+	Protein  *float64 `json:"protein"`
+	Fat      *float64 `json:"fat"`
+	Carbs    *float64 `json:"carbs"`
+}
+
+type CorrectionResponse struct {
+	AnalysisID    string            `json:"analysis_id"`
+	FoodName      string            `json:"food_name"`
+	Model         string            `json:"model"`
+	PromptVersion string            `json:"prompt_version"`
+	Original      Nutrients         `json:"original"`
+	Corrected     CorrectionRequest `json:"corrected"`
+	CorrectedAt   string            `json:"corrected_at"`
+}
+
+type AccuracyReportResponse struct {
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	PromptVersions []AccuracyGroup `json:"prompt_versions"`
+	Foods          []AccuracyGroup `json:"foods"`
+}
+
+// AccuracyGroup is the mean absolute error of corrected analyses; a nutrient
+// is null when no correction in the group covered it
+type AccuracyGroup struct {
+	FoodName      string   `json:"food_name,omitempty"`
+	PromptVersion string   `json:"prompt_version"`
+	Samples       int      `json:"samples"`
+	MAE           MAEStats `json:"mae"`
+}
+
+type MAEStats struct {
+	Calories *float64 `json:"calories"`
+	Protein  *float64 `json:"protein"`
+	Fat      *float64 `json:"fat"`
+	Carbs    *float64 `json:"carbs"`
+}
+
+func (h *httpHandler) handleCorrectAnalysis(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	var req CorrectionRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	analysis, err := h.svc.CorrectAnalysis(r.Context(), userID, id, domain.Correction{
+		Calories: req.Calories,
+		Protein:  req.Protein,
+		Fat:      req.Fat,
+		Carbs:    req.Carbs,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CorrectionResponse{
+		AnalysisID:    analysis.ID.String(),
+		FoodName:      analysis.FoodName,
+		Model:         analysis.Model,
+		PromptVersion: analysis.PromptVersion,
+		Original:      toNutrients(analysis.Original),
+		Corrected: CorrectionRequest{
+			Calories: analysis.Correction.Calories,
+			Protein:  analysis.Correction.Protein,
+			Fat:      analysis.Correction.Fat,
+			Carbs:    analysis.Correction.Carbs,
+		},
+		CorrectedAt: analysis.Correction.CorrectedAt.Format(time.RFC3339),
+	})
+}
+
+func (h *httpHandler) handleAccuracyReport(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	query := r.URL.Query()
+
+	// Default to the last 30 days
+	var opts dietsvc.AccuracyReportOptions
+	var err error
+	opts.To = time.Now().UTC()
+	if to := query.Get("to"); to != "" {
+		if opts.To, err = time.Parse(dateLayout, to); err != nil {
+			writeError(w, domain.InvalidAccuracyQuery("to", "must be a YYYY-MM-DD date"))
+			return
+		}
+	}
+	opts.From = opts.To.AddDate(0, 0, -29)
+	if from := query.Get("from"); from != "" {
+		if opts.From, err = time.Parse(dateLayout, from); err != nil {
+			writeError(w, domain.InvalidAccuracyQuery("from", "must be a YYYY-MM-DD date"))
+			return
+		}
+	}
+	if minSamples := query.Get("min_samples"); minSamples != "" {
+		if opts.MinSamples, err = strconv.Atoi(minSamples); err != nil || opts.MinSamples < 1 {
+			writeError(w, domain.InvalidAccuracyQuery("min_samples", "must be a positive integer"))
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if opts.Limit, err = strconv.Atoi(limit); err != nil || opts.Limit < 1 {
+			writeError(w, domain.InvalidAccuracyQuery("limit", "must be a positive integer"))
+			return
+		}
+	}
+
+	report, err := h.svc.AccuracyReport(r.Context(), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := AccuracyReportResponse{
+		From:           opts.From.Format(dateLayout),
+		To:             opts.To.Format(dateLayout),
+		PromptVersions: make([]AccuracyGroup, 0, len(report.PromptVersions)),
+		Foods:          make([]AccuracyGroup, 0, len(report.Foods)),
+	}
+	for _, group := range report.PromptVersions {
+		response.PromptVersions = append(response.PromptVersions, toAccuracyGroup(group))
+	}
+	for _, group := range report.Foods {
+		response.Foods = append(response.Foods, toAccuracyGroup(group))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func toAccuracyGroup(group domain.AccuracyGroup) AccuracyGroup {
+	return AccuracyGroup{
+		FoodName:      group.FoodName,
+		PromptVersion: group.PromptVersion,
+		Samples:       group.Samples,
+		MAE: MAEStats{
+			Calories: group.MAE.Calories,
+			Protein:  group.MAE.Protein,
+			Fat:      group.MAE.Fat,
+			Carbs:    group.MAE.Carbs,
+		},
+	}
+}
@@ -0,0 +1,409 @@
+package dietapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
+
+type DietEntry struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Calories    float64 `json:"calories"`
+	Protein     float64 `json:"protein"`
+	Fat         float64 `json:"fat"`
+	Carbs       float64 `json:"carbs"`
+	EatenAt     string  `json:"eaten_at"`
+	ImageRef    string  `json:"image_ref,omitempty"`
+	Source      string  `json:"source"`
+	// MealType is omitted for entries logged before meal types were recorded
+	MealType   string   `json:"meal_type,omitempty"`
+	Confidence *float64 `json:"confidence,omitempty"`
+	// AnalysisID, Model and PromptVersion trace the entry to the analysis
+	// its values came from; omitted for manual and copied entries
+	AnalysisID    string `json:"analysis_id,omitempty"`
+	Model         string `json:"model,omitempty"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	// Micronutrients are null when unknown
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	// PercentOfTarget is the share of the user's daily targets this entry
+	// covers and Remaining is the targets minus everything logged on the
+	// entry's local day, negative once a target is exceeded; both are omitted
+	// when the user has no targets
+	PercentOfTarget *Nutrients `json:"percent_of_target,omitempty"`
+	Remaining       *Nutrients `json:"remaining,omitempty"`
+}
+
+type EntryRequest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Calories    float64   `json:"calories"`
+	Protein     float64   `json:"protein"`
+	Fat         float64   `json:"fat"`
+	Carbs       float64   `json:"carbs"`
+	EatenAt     time.Time `json:"eaten_at"`
+	ImageRef    string    `json:"image_ref"`
+	Source      string    `json:"source"`
+	// MealType is inferred from the local time of EatenAt when omitted
+	MealType   string   `json:"meal_type"`
+	Confidence *float64 `json:"confidence"`
+	// AnalysisID is the analysis_id of the analysis the values came from
+	AnalysisID   *uuid.UUID `json:"analysis_id"`
+	Fiber        *float64   `json:"fiber"`
+	Sugar        *float64   `json:"sugar"`
+	SaturatedFat *float64   `json:"saturated_fat"`
+	SodiumMg     *float64   `json:"sodium_mg"`
+}
+
+// LogRequest logs servings of saved values, such as a recipe or an earlier
+// entry
+type LogRequest struct {
+	// Servings defaults to 1
+	Servings float64   `json:"servings"`
+	EatenAt  time.Time `json:"eaten_at"`
+	// MealType is inferred from the local time of EatenAt when omitted
+	MealType string `json:"meal_type"`
+}
+
+type ListEntriesResponse struct {
+	Entries    []DietEntry `json:"entries"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+func (h *httpHandler) handleListEntries(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	query := r.URL.Query()
+	opts := dietsvc.ListEntriesOptions{
+		Cursor: query.Get("cursor"),
+	}
+
+	var err error
+	if opts.From, err = parseTimeParam(query.Get("from")); err != nil {
+		writeError(w, httperrors.New(400, "INVALID_QUERY", "from must be an RFC 3339 timestamp", "from"))
+		return
+	}
+	if opts.To, err = parseTimeParam(query.Get("to")); err != nil {
+		writeError(w, httperrors.New(400, "INVALID_QUERY", "to must be an RFC 3339 timestamp", "to"))
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if opts.Limit, err = strconv.Atoi(limit); err != nil {
+			writeError(w, httperrors.New(400, "INVALID_QUERY", "limit must be a number", "limit"))
+			return
+		}
+	}
+
+	loc, err := timeZoneParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := h.svc.ListEntries(r.Context(), userID, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := h.toDietEntries(r.Context(), userID, page.Entries, loc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListEntriesResponse{
+		Entries:    entries,
+		NextCursor: page.NextCursor,
+	})
+}
+
+func (h *httpHandler) handleCreateEntry(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	loc, err := timeZoneParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req EntryRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry, err := h.svc.CreateEntry(r.Context(), userID, req.toDomain())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := h.toDietEntries(r.Context(), userID, []domain.DietEntry{*entry}, loc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entries[0])
+}
+
+func (h *httpHandler) handleGetEntry(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	loc, err := timeZoneParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry, err := h.svc.GetEntry(r.Context(), userID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := h.toDietEntries(r.Context(), userID, []domain.DietEntry{*entry}, loc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entries[0])
+}
+
+func (h *httpHandler) handleUpdateEntry(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	loc, err := timeZoneParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req EntryRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry := req.toDomain()
+	entry.ID = id
+
+	updated, err := h.svc.UpdateEntry(r.Context(), userID, entry)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := h.toDietEntries(r.Context(), userID, []domain.DietEntry{*updated}, loc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entries[0])
+}
+
+func (h *httpHandler) handleDeleteEntry(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	if err := h.svc.DeleteEntry(r.Context(), userID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *httpHandler) handleRelogEntry(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	h.handleLog(w, r, userID, id, h.svc.RelogEntry)
+}
+
+// handleLog decodes a LogRequest for the saved values with the given ID and
+// responds with the new entry like handleCreateEntry
+func (h *httpHandler) handleLog(w http.ResponseWriter, r *http.Request, userID, id uuid.UUID, create func(context.Context, uuid.UUID, uuid.UUID, dietsvc.LogOptions) (*domain.DietEntry, error)) {
+	loc, err := timeZoneParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req LogRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry, err := create(r.Context(), userID, id, dietsvc.LogOptions{
+		Servings: req.Servings,
+		EatenAt:  req.EatenAt,
+		MealType: domain.MealType(req.MealType),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := h.toDietEntries(r.Context(), userID, []domain.DietEntry{*entry}, loc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entries[0])
+}
+
+func (req EntryRequest) toDomain() domain.DietEntry {
+	return domain.DietEntry{
+		Name:        req.Name,
+		Description: req.Description,
+		Calories:    req.Calories,
+		Protein:     req.Protein,
+		Fat:         req.Fat,
+		Carbs:       req.Carbs,
+		EatenAt:     req.EatenAt,
+		ImageRef:    req.ImageRef,
+		Source:      domain.EntrySource(req.Source),
+		MealType:    domain.MealType(req.MealType),
+		Confidence:  req.Confidence,
+		AnalysisID:  req.AnalysisID,
+		Micronutrients: domain.Micronutrients{
+			Fiber:        req.Fiber,
+			Sugar:        req.Sugar,
+			SaturatedFat: req.SaturatedFat,
+			SodiumMg:     req.SodiumMg,
+		},
+	}
+}
+
+// toDietEntries converts entries with their progress against the user's
+// targets, each compared with the totals of its local day in loc
+func (h *httpHandler) toDietEntries(ctx context.Context, userID uuid.UUID, entries []domain.DietEntry, loc *time.Location) ([]DietEntry, error) {
+	targets, err := h.svc.DailyTargets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dayTotals map[string]domain.Nutrients
+	if targets != nil {
+		if dayTotals, err = h.svc.DayTotals(ctx, userID, entries, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	response := make([]DietEntry, 0, len(entries))
+	for i := range entries {
+		var totals *domain.Nutrients
+		if day, ok := dayTotals[entries[i].EatenAt.In(loc).Format(dateLayout)]; ok {
+			totals = &day
+		}
+		response = append(response, toDietEntry(&entries[i], targets, totals))
+	}
+
+	return response, nil
+}
+
+// toDietEntry reports progress when targets is set. dayTotals are the totals
+// of the entry's local day; Remaining is omitted without them.
+func toDietEntry(entry *domain.DietEntry, targets, dayTotals *domain.Nutrients) DietEntry {
+	response := DietEntry{
+		ID:            entry.ID.String(),
+		Name:          entry.Name,
+		Description:   entry.Description,
+		Calories:      entry.Calories,
+		Protein:       entry.Protein,
+		Fat:           entry.Fat,
+		Carbs:         entry.Carbs,
+		EatenAt:       entry.EatenAt.Format(time.RFC3339),
+		ImageRef:      entry.ImageRef,
+		Source:        string(entry.Source),
+		MealType:      string(entry.MealType),
+		Confidence:    entry.Confidence,
+		Fiber:         entry.Fiber,
+		Sugar:         entry.Sugar,
+		SaturatedFat:  entry.SaturatedFat,
+		SodiumMg:      entry.SodiumMg,
+		CreatedAt:     entry.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     entry.UpdatedAt.Format(time.RFC3339),
+		Model:         entry.Model,
+		PromptVersion: entry.PromptVersion,
+	}
+	if entry.AnalysisID != nil {
+		response.AnalysisID = entry.AnalysisID.String()
+	}
+
+	if targets != nil {
+		consumed := domain.Nutrients{
+			Calories: entry.Calories,
+			Protein:  entry.Protein,
+			Fat:      entry.Fat,
+			Carbs:    entry.Carbs,
+		}
+		percent := toNutrients(domain.NewProgress(*targets, consumed).Percent)
+		response.PercentOfTarget = &percent
+
+		if dayTotals != nil {
+			remaining := toNutrients(domain.NewProgress(*targets, *dayTotals).Remaining)
+			response.Remaining = &remaining
+		}
+	}
+
+	return response
+}
+
+// timeZoneParam loads the optional tz query parameter, an IANA time zone
+// name defaulting to UTC
+func timeZoneParam(r *http.Request) (*time.Location, error) {
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return nil, httperrors.New(400, "INVALID_QUERY", "tz must be an IANA time zone name", "tz")
+	}
+
+	return loc, nil
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
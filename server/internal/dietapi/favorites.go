@@ -0,0 +1,169 @@
+package dietapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
+
+type RecentFood struct {
+	// Entry is the latest entry of the food; relog it with
+	// POST /diet/entries/{id}/relog
+	Entry    DietEntry `json:"entry"`
+	LogCount int       `json:"log_count"`
+}
+
+type RecentsResponse struct {
+	Recents []RecentFood `json:"recents"`
+}
+
+type Favorite struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Calories    float64  `json:"calories"`
+	Protein     float64  `json:"protein"`
+	Fat         float64  `json:"fat"`
+	Carbs       float64  `json:"carbs"`
+	Source      string   `json:"source"`
+	Confidence  *float64 `json:"confidence,omitempty"`
+	// Micronutrients are null when unknown
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+type FavoriteRequest struct {
+	EntryID uuid.UUID `json:"entry_id"`
+}
+
+type ListFavoritesResponse struct {
+	Favorites []Favorite `json:"favorites"`
+}
+
+func (h *httpHandler) handleRecents(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	query := r.URL.Query()
+
+	opts := dietsvc.RecentsOptions{
+		Order: domain.RecentsOrder(query.Get("sort")),
+	}
+	if opts.Order == "" {
+		opts.Order = domain.RecentsOrderRecent
+	}
+	if !opts.Order.IsValid() {
+		writeError(w, httperrors.New(400, "INVALID_QUERY", "sort must be recent or frequent", "sort"))
+		return
+	}
+	if raw := query.Get("limit"); raw != "" {
+		var err error
+		if opts.Limit, err = strconv.Atoi(raw); err != nil {
+			writeError(w, httperrors.New(400, "INVALID_QUERY", "limit must be a number", "limit"))
+			return
+		}
+	}
+
+	recents, err := h.svc.Recents(r.Context(), userID, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := RecentsResponse{
+		Recents: make([]RecentFood, 0, len(recents)),
+	}
+	for _, recent := range recents {
+		response.Recents = append(response.Recents, RecentFood{
+			Entry:    toDietEntry(&recent.Entry, nil, nil),
+			LogCount: recent.LogCount,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *httpHandler) handleListFavorites(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	favorites, err := h.svc.ListFavorites(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := ListFavoritesResponse{
+		Favorites: make([]Favorite, 0, len(favorites)),
+	}
+	for i := range favorites {
+		response.Favorites = append(response.Favorites, toFavorite(&favorites[i]))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *httpHandler) handleAddFavorite(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	var req FavoriteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	favorite, err := h.svc.AddFavorite(r.Context(), userID, req.EntryID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toFavorite(favorite))
+}
+
+func (h *httpHandler) handleDeleteFavorite(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	if err := h.svc.DeleteFavorite(r.Context(), userID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *httpHandler) handleLogFavorite(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	h.handleLog(w, r, userID, id, h.svc.LogFavorite)
+}
+
+func toFavorite(favorite *domain.Favorite) Favorite {
+	return Favorite{
+		ID:           favorite.ID.String(),
+		Name:         favorite.Name,
+		Description:  favorite.Description,
+		Calories:     favorite.Calories,
+		Protein:      favorite.Protein,
+		Fat:          favorite.Fat,
+		Carbs:        favorite.Carbs,
+		Source:       string(favorite.Source),
+		Confidence:   favorite.Confidence,
+		Fiber:        favorite.Fiber,
+		Sugar:        favorite.Sugar,
+		SaturatedFat: favorite.SaturatedFat,
+		SodiumMg:     favorite.SodiumMg,
+		CreatedAt:    favorite.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    favorite.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,92 @@
+package dietapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
+
+// FoodNutrients are per 100 g; micronutrients are null when unknown
+type FoodNutrients struct {
+	Calories     float64  `json:"calories"`
+	Protein      float64  `json:"protein"`
+	Fat          float64  `json:"fat"`
+	Carbs        float64  `json:"carbs"`
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+}
+
+type Serving struct {
+	Label string  `json:"label"`
+	Grams float64 `json:"grams"`
+}
+
+type Food struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Category string        `json:"category,omitempty"`
+	Source   string        `json:"source"`
+	Per100g  FoodNutrients `json:"per_100g"`
+	Servings []Serving     `json:"servings"`
+}
+
+type SearchFoodsResponse struct {
+	Foods []Food `json:"foods"`
+}
+
+func (h *httpHandler) handleSearchFoods(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var limit int
+	if raw := query.Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil {
+			writeError(w, httperrors.New(400, "INVALID_QUERY", "limit must be a number", "limit"))
+			return
+		}
+	}
+
+	foods, err := h.svc.SearchFoods(r.Context(), query.Get("q"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := SearchFoodsResponse{
+		Foods: make([]Food, 0, len(foods)),
+	}
+	for _, food := range foods {
+		response.Foods = append(response.Foods, toFood(food))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func toFood(food domain.Food) Food {
+	response := Food{
+		ID:       food.ID.String(),
+		Name:     food.Name,
+		Category: food.Category,
+		Source:   food.Source,
+		Per100g: FoodNutrients{
+			Calories:     food.Calories,
+			Protein:      food.Protein,
+			Fat:          food.Fat,
+			Carbs:        food.Carbs,
+			Fiber:        food.Fiber,
+			Sugar:        food.Sugar,
+			SaturatedFat: food.SaturatedFat,
+			SodiumMg:     food.SodiumMg,
+		},
+		Servings: make([]Serving, 0, len(food.Servings)),
+	}
+	for _, serving := range food.Servings {
+		response.Servings = append(response.Servings, Serving{Label: serving.Label, Grams: serving.Grams})
+	}
+
+	return response
+}
@@ -1,94 +1,440 @@
 package dietapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/authsvc"
+	authdomain "github.com/priyanshujain/balancewise/server/internal/authsvc/domain"
 	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
 	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
 	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+	"github.com/priyanshujain/balancewise/server/internal/jwt"
 )
 
 type httpHandler struct {
 	http.ServeMux
-	svc *dietsvc.Service
+	svc     *dietsvc.Service
+	authSvc *authsvc.Service
+	// adminEmails are the lowercased emails allowed to use admin endpoints
+	adminEmails map[string]bool
 }
 
 type AnalyzeResponse struct {
-	FoodName string  `json:"food_name"`
-	Calories float64 `json:"calories"`
-	Protein  float64 `json:"protein"`
-	Fat      float64 `json:"fat"`
-	Carbs    float64 `json:"carbs"`
+	FoodName          string         `json:"food_name"`
+	Calories          float64        `json:"calories"`
+	Protein           float64        `json:"protein"`
+	Fat               float64        `json:"fat"`
+	Carbs             float64        `json:"carbs"`
+	Fiber             *float64       `json:"fiber"`
+	Sugar             *float64       `json:"sugar"`
+	SaturatedFat      *float64       `json:"saturated_fat"`
+	SodiumMg          *float64       `json:"sodium_mg"`
+	Items             []AnalyzedItem `json:"items"`
+	Confidence        float64        `json:"confidence"`
+	NeedsConfirmation bool           `json:"needs_confirmation"`
+	Cached            bool           `json:"cached"`
+	// MealType is omitted when it could not be determined
+	MealType string `json:"meal_type,omitempty"`
+	// Allergens are found in any item; Warnings flag conflicts with the
+	// user's restrictions, which were only checked if RestrictionsChecked
+	Allergens           []string             `json:"allergens"`
+	Warnings            []RestrictionWarning `json:"warnings"`
+	RestrictionsChecked bool                 `json:"restrictions_checked"`
+	// Model and PromptVersion identify what produced the analysis; omitted
+	// for label lookups
+	Model         string `json:"model,omitempty"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	// AnalysisID is used to submit a correction; omitted for label lookups
+	AnalysisID string `json:"analysis_id,omitempty"`
 }
 
-func NewHandler(svc *dietsvc.Service) http.Handler {
+type AnalyzedItem struct {
+	Name         string   `json:"name"`
+	PortionGrams float64  `json:"portion_grams"`
+	Servings     float64  `json:"servings"`
+	Calories     float64  `json:"calories"`
+	Protein      float64  `json:"protein"`
+	Fat          float64  `json:"fat"`
+	Carbs        float64  `json:"carbs"`
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+	Ingredients  []string `json:"ingredients"`
+	Allergens    []string `json:"allergens"`
+	// Diet is omitted when unknown
+	Diet string `json:"diet,omitempty"`
+}
+
+type RestrictionWarning struct {
+	Restriction string `json:"restriction"`
+	Item        string `json:"item"`
+	// Allergen is omitted for diet conflicts
+	Allergen string `json:"allergen,omitempty"`
+	Message  string `json:"message"`
+}
+
+type AnalyzeTextRequest struct {
+	Description string `json:"description"`
+	// MealType overrides the inferred meal type
+	MealType string `json:"meal_type"`
+	// EatenAt is when the meal was eaten, with the user's UTC offset
+	EatenAt *time.Time `json:"eaten_at"`
+	// Async queues the analysis as a job instead of waiting for the result
+	Async       bool   `json:"async"`
+	CallbackURL string `json:"callback_url"`
+}
+
+const (
+	// responseMargin is left between an analysis deadline and the write
+	// deadline to write the response
+	responseMargin = 5 * time.Second
+	// analyzeTimeout bounds synchronous analyses so the provider is not
+	// retried after the server's 30 second write timeout has lost the response
+	analyzeTimeout = 30*time.Second - responseMargin
+	// maxJSONBodySize bounds JSON request bodies
+	maxJSONBodySize = 64 << 10
+)
+
+var (
+	errInvalidRequestBody = httperrors.New(400, "INVALID_REQUEST_BODY", "invalid request body")
+	errFormParse          = httperrors.New(400, "FORM_PARSE_ERROR", "failed to parse multipart form")
+	errImageRead          = httperrors.New(400, "IMAGE_READ_ERROR", "failed to read image data")
+	errRequestTooLarge    = httperrors.New(413, "REQUEST_TOO_LARGE", "request body must not exceed 64KB")
+	errForbidden          = httperrors.New(403, "FORBIDDEN", "admin access required")
+)
+
+func NewHandler(svc *dietsvc.Service, authSvc *authsvc.Service, adminEmails []string) http.Handler {
 	h := &httpHandler{
-		svc: svc,
+		svc:         svc,
+		authSvc:     authSvc,
+		adminEmails: make(map[string]bool, len(adminEmails)),
+	}
+	for _, email := range adminEmails {
+		h.adminEmails[strings.ToLower(strings.TrimSpace(email))] = true
 	}
 	h.init()
 	return h
 }
 
 func (h *httpHandler) init() {
-	h.HandleFunc("POST /diet/analyze", corsMiddleware(h.handleAnalyze))
+	h.HandleFunc("POST /diet/analyze", corsMiddleware(h.withAuth(h.handleAnalyze)))
+	h.HandleFunc("POST /diet/analyze-batch", corsMiddleware(h.withAuth(h.handleAnalyzeBatch)))
+	h.HandleFunc("POST /diet/analyze-text", corsMiddleware(h.withAuth(h.handleAnalyzeText)))
+	h.HandleFunc("GET /diet/entries", corsMiddleware(h.withAuth(h.handleListEntries)))
+	h.HandleFunc("POST /diet/entries", corsMiddleware(h.withAuth(h.handleCreateEntry)))
+	h.HandleFunc("GET /diet/entries/{id}", corsMiddleware(h.withAuth(h.handleGetEntry)))
+	h.HandleFunc("PUT /diet/entries/{id}", corsMiddleware(h.withAuth(h.handleUpdateEntry)))
+	h.HandleFunc("DELETE /diet/entries/{id}", corsMiddleware(h.withAuth(h.handleDeleteEntry)))
+	h.HandleFunc("POST /diet/entries/{id}/relog", corsMiddleware(h.withAuth(h.handleRelogEntry)))
+	h.HandleFunc("GET /diet/summary", corsMiddleware(h.withAuth(h.handleSummary)))
+	h.HandleFunc("GET /diet/meals", corsMiddleware(h.withAuth(h.handleMeals)))
+	h.HandleFunc("GET /diet/settings", corsMiddleware(h.withAuth(h.handleGetSettings)))
+	h.HandleFunc("PUT /diet/settings", corsMiddleware(h.withAuth(h.handleUpdateSettings)))
+	h.HandleFunc("GET /diet/foods", corsMiddleware(h.handleSearchFoods))
+	h.HandleFunc("GET /diet/barcode/{ean}", corsMiddleware(h.handleBarcode))
+	h.HandleFunc("GET /diet/jobs/{id}", corsMiddleware(h.withAuth(h.handleGetJob)))
+	h.HandleFunc("GET /diet/usage", corsMiddleware(h.withAuth(h.handleGetUsage)))
+	h.HandleFunc("GET /diet/restrictions", corsMiddleware(h.withAuth(h.handleGetRestrictions)))
+	h.HandleFunc("PUT /diet/restrictions", corsMiddleware(h.withAuth(h.handleUpdateRestrictions)))
+	h.HandleFunc("GET /diet/recipes", corsMiddleware(h.withAuth(h.handleListRecipes)))
+	h.HandleFunc("POST /diet/recipes", corsMiddleware(h.withAuth(h.handleCreateRecipe)))
+	h.HandleFunc("GET /diet/recipes/{id}", corsMiddleware(h.withAuth(h.handleGetRecipe)))
+	h.HandleFunc("PUT /diet/recipes/{id}", corsMiddleware(h.withAuth(h.handleUpdateRecipe)))
+	h.HandleFunc("DELETE /diet/recipes/{id}", corsMiddleware(h.withAuth(h.handleDeleteRecipe)))
+	h.HandleFunc("POST /diet/recipes/{id}/log", corsMiddleware(h.withAuth(h.handleLogRecipe)))
+	h.HandleFunc("GET /diet/recents", corsMiddleware(h.withAuth(h.handleRecents)))
+	h.HandleFunc("GET /diet/favorites", corsMiddleware(h.withAuth(h.handleListFavorites)))
+	h.HandleFunc("POST /diet/favorites", corsMiddleware(h.withAuth(h.handleAddFavorite)))
+	h.HandleFunc("DELETE /diet/favorites/{id}", corsMiddleware(h.withAuth(h.handleDeleteFavorite)))
+	h.HandleFunc("POST /diet/favorites/{id}/log", corsMiddleware(h.withAuth(h.handleLogFavorite)))
+	h.HandleFunc("GET /diet/meal-plans", corsMiddleware(h.withAuth(h.handleListMealPlans)))
+	h.HandleFunc("POST /diet/meal-plans", corsMiddleware(h.withAuth(h.handleCreateMealPlan)))
+	h.HandleFunc("GET /diet/meal-plans/{id}", corsMiddleware(h.withAuth(h.handleGetMealPlan)))
+	h.HandleFunc("POST /diet/meal-plans/{id}/days/{date}/regenerate", corsMiddleware(h.withAuth(h.handleRegenerateMealPlanDay)))
+	h.HandleFunc("POST /diet/analyses/{id}/correction", corsMiddleware(h.withAuth(h.handleCorrectAnalysis)))
+	h.HandleFunc("GET /diet/analyses/report", corsMiddleware(h.withAdmin(h.handleAccuracyReport)))
 }
 
-func (h *httpHandler) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+func (h *httpHandler) handleAnalyze(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
 	if err := r.ParseMultipartForm(5 << 20); err != nil {
-		httpErr := httperrors.New(400, "FORM_PARSE_ERROR", "failed to parse multipart form")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(httpErr.HttpStatus)
-		json.NewEncoder(w).Encode(httpErr)
+		writeError(w, errFormParse)
 		return
 	}
 
-	file, header, err := r.FormFile("image")
+	file, _, err := r.FormFile("image")
 	if err != nil {
-		httpErr := httperrors.From(domain.ErrNoImageProvided)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(httpErr.HttpStatus)
-		json.NewEncoder(w).Encode(httpErr)
+		writeError(w, domain.ErrNoImageProvided)
 		return
 	}
 	defer file.Close()
 
 	imageData, err := io.ReadAll(file)
 	if err != nil {
-		httpErr := httperrors.New(400, "IMAGE_READ_ERROR", "failed to read image data")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(httpErr.HttpStatus)
-		json.NewEncoder(w).Encode(httpErr)
+		writeError(w, errImageRead)
 		return
 	}
 
-	mimeType := header.Header.Get("Content-Type")
+	hints, err := hintsFromForm(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	bypassCache := bypassCacheRequested(r)
+
+	if r.FormValue("async") == "true" {
+		h.submitJob(w, r, dietsvc.SubmitJobOptions{
+			UserID:      userID,
+			Kind:        domain.JobKindImage,
+			ImageData:   imageData,
+			Hints:       hints,
+			BypassCache: bypassCache,
+			CallbackURL: r.FormValue("callback_url"),
+		})
+		return
+	}
 
-	ctx := r.Context()
-	analysis, err := h.svc.AnalyzeFood(ctx, imageData, mimeType)
+	ctx, cancel := context.WithTimeout(r.Context(), analyzeTimeout)
+	defer cancel()
+	result, err := h.svc.AnalyzeFood(ctx, dietsvc.AnalyzeFoodOptions{
+		UserID:      userID,
+		ImageData:   imageData,
+		Hints:       hints,
+		BypassCache: bypassCache,
+	})
 	if err != nil {
 		slog.Error("failed to analyze food", "error", err)
-		httpErr := httperrors.From(err)
-		w.WriteHeader(httpErr.HttpStatus)
-		json.NewEncoder(w).Encode(httpErr)
+		writeError(w, err)
+		return
+	}
+
+	response := toAnalyzeResponse(result.Analysis)
+	response.Cached = result.Cached
+
+	writeJSON(w, http.StatusOK, response)
+
+	slog.Info("analyzed food image", "food", result.Analysis.FoodName, "cached", result.Cached)
+}
+
+func (h *httpHandler) handleAnalyzeText(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	var req AnalyzeTextRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 
+	hints := domain.AnalysisHints{
+		MealType:  domain.MealType(req.MealType),
+		LocalTime: req.EatenAt,
+	}
+
+	if req.Async {
+		h.submitJob(w, r, dietsvc.SubmitJobOptions{
+			UserID:      userID,
+			Kind:        domain.JobKindText,
+			Description: req.Description,
+			Hints:       hints,
+			CallbackURL: req.CallbackURL,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), analyzeTimeout)
+	defer cancel()
+	analysis, err := h.svc.AnalyzeFoodText(ctx, userID, req.Description, hints)
+	if err != nil {
+		slog.Error("failed to analyze meal description", "error", err)
+		writeError(w, err)
+		return
+	}
+
+	response := toAnalyzeResponse(analysis)
+
+	writeJSON(w, http.StatusOK, response)
+
+	slog.Info("analyzed meal description", "food", analysis.FoodName)
+}
+
+// hintsFromForm reads the optional analysis hint fields of a multipart form
+func hintsFromForm(r *http.Request) (domain.AnalysisHints, error) {
+	hints := domain.AnalysisHints{
+		PortionSize: r.FormValue("portion"),
+		Cuisine:     r.FormValue("cuisine"),
+		MealType:    domain.MealType(r.FormValue("meal_type")),
+		Notes:       r.FormValue("notes"),
+	}
+	if eatenAt := r.FormValue("eaten_at"); eatenAt != "" {
+		localTime, err := time.Parse(time.RFC3339, eatenAt)
+		if err != nil {
+			return hints, domain.InvalidHints("eaten_at", "must be an RFC 3339 timestamp")
+		}
+		hints.LocalTime = &localTime
+	}
+	if servings := r.FormValue("servings"); servings != "" {
+		var err error
+		hints.Servings, err = strconv.ParseFloat(servings, 64)
+		if err != nil {
+			return hints, domain.InvalidHints("servings", "must be a number")
+		}
+	}
+
+	return hints, nil
+}
+
+// bypassCacheRequested reports whether the client forced a fresh analysis,
+// with a bypass_cache form field or a Cache-Control: no-cache header
+func bypassCacheRequested(r *http.Request) bool {
+	return r.FormValue("bypass_cache") == "true" ||
+		strings.Contains(r.Header.Get("Cache-Control"), "no-cache")
+}
+
+func toAnalyzeResponse(analysis *domain.DietAnalysis) AnalyzeResponse {
 	response := AnalyzeResponse{
-		FoodName: analysis.FoodName,
-		Calories: analysis.Calories,
-		Protein:  analysis.Protein,
-		Fat:      analysis.Fat,
-		Carbs:    analysis.Carbs,
+		FoodName:            analysis.FoodName,
+		Calories:            analysis.Calories,
+		Protein:             analysis.Protein,
+		Fat:                 analysis.Fat,
+		Carbs:               analysis.Carbs,
+		Fiber:               analysis.Fiber,
+		Sugar:               analysis.Sugar,
+		SaturatedFat:        analysis.SaturatedFat,
+		SodiumMg:            analysis.SodiumMg,
+		Items:               make([]AnalyzedItem, 0, len(analysis.Items)),
+		Confidence:          analysis.Confidence,
+		NeedsConfirmation:   analysis.IsLowConfidence(),
+		MealType:            string(analysis.MealType),
+		Allergens:           toStrings(analysis.Allergens()),
+		Warnings:            make([]RestrictionWarning, 0, len(analysis.Warnings)),
+		RestrictionsChecked: analysis.RestrictionsChecked,
+		Model:               analysis.Model,
+		PromptVersion:       analysis.PromptVersion,
+	}
+	if analysis.ID != uuid.Nil {
+		response.AnalysisID = analysis.ID.String()
+	}
+
+	for _, warning := range analysis.Warnings {
+		response.Warnings = append(response.Warnings, RestrictionWarning{
+			Restriction: string(warning.Restriction),
+			Item:        warning.Item,
+			Allergen:    string(warning.Allergen),
+			Message:     warning.Message,
+		})
+	}
+
+	for _, item := range analysis.Items {
+		ingredients := item.Ingredients
+		if ingredients == nil {
+			ingredients = []string{}
+		}
+
+		response.Items = append(response.Items, AnalyzedItem{
+			Name:         item.Name,
+			PortionGrams: item.PortionGrams,
+			Servings:     item.Servings,
+			Calories:     item.Calories,
+			Protein:      item.Protein,
+			Fat:          item.Fat,
+			Carbs:        item.Carbs,
+			Fiber:        item.Fiber,
+			Sugar:        item.Sugar,
+			SaturatedFat: item.SaturatedFat,
+			SodiumMg:     item.SodiumMg,
+			Ingredients:  ingredients,
+			Allergens:    toStrings(item.Allergens),
+			Diet:         string(item.Diet),
+		})
+	}
+
+	return response
+}
+
+// toStrings converts a slice of string-backed values, never returning nil so
+// it encodes as an empty JSON array
+func toStrings[T ~string](values []T) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, string(v))
+	}
+	return out
+}
+
+// authedHandlerFunc is an http.HandlerFunc that also receives the
+// authenticated user's ID
+type authedHandlerFunc func(w http.ResponseWriter, r *http.Request, userID uuid.UUID)
+
+// withAuth verifies the bearer token and passes the user's ID to next
+func (h *httpHandler) withAuth(next authedHandlerFunc) http.HandlerFunc {
+	return h.withUser(func(w http.ResponseWriter, r *http.Request, user *authdomain.User) {
+		next(w, r, user.ID)
+	})
+}
+
+// withAdmin is withAuth restricted to users whose email is configured as an
+// admin
+func (h *httpHandler) withAdmin(next authedHandlerFunc) http.HandlerFunc {
+	return h.withUser(func(w http.ResponseWriter, r *http.Request, user *authdomain.User) {
+		if !h.adminEmails[strings.ToLower(user.Email)] {
+			writeError(w, errForbidden)
+			return
+		}
+
+		next(w, r, user.ID)
+	})
+}
+
+// withUser verifies the bearer token and passes the authenticated user to next
+func (h *httpHandler) withUser(next func(w http.ResponseWriter, r *http.Request, user *authdomain.User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenString, err := jwt.ExtractToken(r.Header.Get("Authorization"))
+		if err != nil {
+			writeError(w, authdomain.ErrUnauthorized)
+			return
+		}
+
+		user, err := h.authSvc.VerifyToken(r.Context(), tokenString)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		next(w, r, user)
 	}
+}
 
+func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	httpErr := httperrors.From(err)
+	writeJSON(w, httpErr.HttpStatus, httpErr)
+}
+
+// decodeJSON decodes the request body into v, failing bodies over
+// maxJSONBodySize with errRequestTooLarge
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(v); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return errRequestTooLarge
+		}
+		return errInvalidRequestBody
+	}
 
-	slog.Info("analyzed food image", "food", analysis.FoodName)
+	return nil
 }
 
 func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
@@ -0,0 +1,92 @@
+package dietapi
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type JobAcceptedResponse struct {
+	JobID     string `json:"job_id"`
+	Status    string `json:"status"`
+	StatusURL string `json:"status_url"`
+}
+
+type JobResponse struct {
+	ID       string           `json:"id"`
+	Kind     string           `json:"kind"`
+	Status   string           `json:"status"`
+	Result   *AnalyzeResponse `json:"result,omitempty"`
+	Error    *JobError        `json:"error,omitempty"`
+	Attempts int              `json:"attempts"`
+	// CreatedAt and CompletedAt are RFC 3339 timestamps
+	CreatedAt   string `json:"created_at"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+type JobError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// submitJob queues an analysis and responds with 202 and the job's status URL
+func (h *httpHandler) submitJob(w http.ResponseWriter, r *http.Request, opts dietsvc.SubmitJobOptions) {
+	job, err := h.svc.SubmitJob(r.Context(), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	statusURL := "/diet/jobs/" + job.ID.String()
+	w.Header().Set("Location", statusURL)
+	writeJSON(w, http.StatusAccepted, JobAcceptedResponse{
+		JobID:     job.ID.String(),
+		Status:    string(job.Status),
+		StatusURL: statusURL,
+	})
+
+	slog.Info("submitted analysis job", "job_id", job.ID, "kind", job.Kind)
+}
+
+func (h *httpHandler) handleGetJob(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	job, err := h.svc.GetJob(r.Context(), userID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toJobResponse(job))
+}
+
+func toJobResponse(job *domain.AnalysisJob) JobResponse {
+	response := JobResponse{
+		ID:        job.ID.String(),
+		Kind:      string(job.Kind),
+		Status:    string(job.Status),
+		Attempts:  job.Attempts,
+		CreatedAt: job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.Result != nil {
+		result := toAnalyzeResponse(job.Result)
+		result.Cached = job.Cached
+		response.Result = &result
+	}
+	if job.Error != nil {
+		response.Error = &JobError{Code: job.Error.Code, Message: job.Error.Message}
+	}
+	if job.CompletedAt != nil {
+		response.CompletedAt = job.CompletedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
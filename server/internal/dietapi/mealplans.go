@@ -0,0 +1,200 @@
+package dietapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// mealPlanWriteTimeout replaces the server's write timeout for requests that
+// generate meals, which take longer than an analysis
+const mealPlanWriteTimeout = 3 * time.Minute
+
+type PlannedMeal struct {
+	MealType    string   `json:"meal_type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Ingredients []string `json:"ingredients"`
+	Allergens   []string `json:"allergens"`
+	// Diet is the most restrictive diet the meal fits; omitted when unknown
+	Diet     string  `json:"diet,omitempty"`
+	Calories float64 `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Fat      float64 `json:"fat"`
+	Carbs    float64 `json:"carbs"`
+}
+
+type MealPlanDay struct {
+	Date   string        `json:"date"`
+	Meals  []PlannedMeal `json:"meals"`
+	Totals Nutrients     `json:"totals"`
+	// GeneratedAt, Model and PromptVersion change when the day is regenerated
+	GeneratedAt   string `json:"generated_at"`
+	Model         string `json:"model"`
+	PromptVersion string `json:"prompt_version"`
+}
+
+type MealPlan struct {
+	ID        string `json:"id"`
+	StartDate string `json:"start_date"`
+	// Targets and Restrictions are what the plan was generated for
+	Targets      Nutrients     `json:"targets"`
+	Restrictions []string      `json:"restrictions"`
+	Notes        string        `json:"notes,omitempty"`
+	Days         []MealPlanDay `json:"days"`
+	CreatedAt    string        `json:"created_at"`
+	UpdatedAt    string        `json:"updated_at"`
+}
+
+// MealPlanRequest is optional; an empty body plans from today
+type MealPlanRequest struct {
+	// StartDate is a YYYY-MM-DD date; defaults to today in UTC
+	StartDate string `json:"start_date"`
+	Notes     string `json:"notes"`
+}
+
+type ListMealPlansResponse struct {
+	MealPlans []MealPlan `json:"meal_plans"`
+}
+
+func (h *httpHandler) handleCreateMealPlan(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	var req MealPlanRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	opts := dietsvc.MealPlanOptions{Notes: req.Notes}
+	if req.StartDate != "" {
+		var err error
+		if opts.StartDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			writeError(w, domain.InvalidMealPlan("start_date", "must be a YYYY-MM-DD date"))
+			return
+		}
+	}
+
+	ctx, cancel := extendForMealPlan(w, r)
+	defer cancel()
+	plan, err := h.svc.CreateMealPlan(ctx, userID, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toMealPlan(plan))
+}
+
+func (h *httpHandler) handleListMealPlans(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	plans, err := h.svc.ListMealPlans(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := ListMealPlansResponse{
+		MealPlans: make([]MealPlan, 0, len(plans)),
+	}
+	for i := range plans {
+		response.MealPlans = append(response.MealPlans, toMealPlan(&plans[i]))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *httpHandler) handleGetMealPlan(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	plan, err := h.svc.GetMealPlan(r.Context(), userID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toMealPlan(plan))
+}
+
+func (h *httpHandler) handleRegenerateMealPlanDay(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	date, err := time.Parse(dateLayout, r.PathValue("date"))
+	if err != nil {
+		writeError(w, domain.InvalidMealPlan("date", "must be a YYYY-MM-DD date"))
+		return
+	}
+
+	ctx, cancel := extendForMealPlan(w, r)
+	defer cancel()
+	plan, err := h.svc.RegenerateMealPlanDay(ctx, userID, id, date)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toMealPlan(plan))
+}
+
+// extendForMealPlan extends the write deadline for a request that generates
+// meals and returns its context, bounded to leave time for the response
+func extendForMealPlan(w http.ResponseWriter, r *http.Request) (context.Context, context.CancelFunc) {
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(mealPlanWriteTimeout)); err != nil {
+		slog.Warn("failed to extend write deadline for meal plan", "error", err)
+	}
+
+	return context.WithTimeout(r.Context(), mealPlanWriteTimeout-responseMargin)
+}
+
+func toMealPlan(plan *domain.MealPlan) MealPlan {
+	response := MealPlan{
+		ID:           plan.ID.String(),
+		StartDate:    plan.StartDate.Format(dateLayout),
+		Targets:      toNutrients(plan.Targets),
+		Restrictions: toStrings(plan.Restrictions),
+		Notes:        plan.Notes,
+		Days:         make([]MealPlanDay, 0, len(plan.Days)),
+		CreatedAt:    plan.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    plan.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, day := range plan.Days {
+		planDay := MealPlanDay{
+			Date:          day.Date.Format(dateLayout),
+			Meals:         make([]PlannedMeal, 0, len(day.Meals)),
+			Totals:        toNutrients(day.Totals),
+			GeneratedAt:   day.GeneratedAt.Format(time.RFC3339),
+			Model:         day.Model,
+			PromptVersion: day.PromptVersion,
+		}
+		for _, meal := range day.Meals {
+			planDay.Meals = append(planDay.Meals, PlannedMeal{
+				MealType:    string(meal.MealType),
+				Name:        meal.Name,
+				Description: meal.Description,
+				Ingredients: toStrings(meal.Ingredients),
+				Allergens:   toStrings(meal.Allergens),
+				Diet:        string(meal.Diet),
+				Calories:    meal.Calories,
+				Protein:     meal.Protein,
+				Fat:         meal.Fat,
+				Carbs:       meal.Carbs,
+			})
+		}
+		response.Days = append(response.Days, planDay)
+	}
+
+	return response
+}
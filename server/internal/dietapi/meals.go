@@ -0,0 +1,76 @@
+package dietapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
+
+type Meal struct {
+	MealType   string      `json:"meal_type"`
+	EntryCount int         `json:"entry_count"`
+	Totals     Nutrients   `json:"totals"`
+	Entries    []DietEntry `json:"entries"`
+}
+
+type MealsResponse struct {
+	Date     string    `json:"date"`
+	TimeZone string    `json:"time_zone"`
+	Totals   Nutrients `json:"totals"`
+	Meals    []Meal    `json:"meals"`
+}
+
+func (h *httpHandler) handleMeals(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	query := r.URL.Query()
+
+	loc, err := timeZoneParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Default to today in the user's zone
+	date := time.Now().In(loc)
+	if value := query.Get("date"); value != "" {
+		if date, err = time.ParseInLocation(dateLayout, value, loc); err != nil {
+			writeError(w, httperrors.New(400, "INVALID_QUERY", "date must be a YYYY-MM-DD date", "date"))
+			return
+		}
+	}
+
+	day, err := h.svc.Meals(r.Context(), userID, date, loc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	targets, err := h.svc.DailyTargets(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := MealsResponse{
+		Date:     day.Date.Format(dateLayout),
+		TimeZone: day.TimeZone,
+		Totals:   toNutrients(day.Totals),
+		Meals:    make([]Meal, 0, len(day.Meals)),
+	}
+	for _, meal := range day.Meals {
+		entries := make([]DietEntry, 0, len(meal.Entries))
+		for _, entry := range meal.Entries {
+			entries = append(entries, toDietEntry(&entry, targets, &day.Totals))
+		}
+
+		response.Meals = append(response.Meals, Meal{
+			MealType:   string(meal.Type),
+			EntryCount: len(meal.Entries),
+			Totals:     toNutrients(meal.Totals),
+			Entries:    entries,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,223 @@
+package dietapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// RecipeNutrients are for the stated amount; micronutrients are null when
+// unknown
+type RecipeNutrients struct {
+	Calories     float64  `json:"calories"`
+	Protein      float64  `json:"protein"`
+	Fat          float64  `json:"fat"`
+	Carbs        float64  `json:"carbs"`
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+}
+
+type RecipeIngredient struct {
+	FoodID   string  `json:"food_id"`
+	FoodName string  `json:"food_name"`
+	Grams    float64 `json:"grams"`
+	// Serving and Quantity are omitted when the amount was given in grams
+	Serving   string          `json:"serving,omitempty"`
+	Quantity  float64         `json:"quantity,omitempty"`
+	Nutrients RecipeNutrients `json:"nutrients"`
+}
+
+type Recipe struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Servings    float64            `json:"servings"`
+	Ingredients []RecipeIngredient `json:"ingredients"`
+	Total       RecipeNutrients    `json:"total"`
+	PerServing  RecipeNutrients    `json:"per_serving"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+}
+
+type RecipeIngredientRequest struct {
+	FoodID uuid.UUID `json:"food_id"`
+	// Either Grams, or a Serving label of the food and a Quantity of it
+	Grams    float64 `json:"grams"`
+	Serving  string  `json:"serving"`
+	Quantity float64 `json:"quantity"`
+}
+
+type RecipeRequest struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Servings    float64                   `json:"servings"`
+	Ingredients []RecipeIngredientRequest `json:"ingredients"`
+}
+
+type ListRecipesResponse struct {
+	Recipes []Recipe `json:"recipes"`
+}
+
+func (h *httpHandler) handleListRecipes(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	recipes, err := h.svc.ListRecipes(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := ListRecipesResponse{
+		Recipes: make([]Recipe, 0, len(recipes)),
+	}
+	for i := range recipes {
+		response.Recipes = append(response.Recipes, toRecipe(&recipes[i]))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *httpHandler) handleCreateRecipe(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	var req RecipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	created, err := h.svc.CreateRecipe(r.Context(), userID, req.toDomain())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toRecipe(created))
+}
+
+func (h *httpHandler) handleGetRecipe(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	recipe, err := h.svc.GetRecipe(r.Context(), userID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRecipe(recipe))
+}
+
+func (h *httpHandler) handleUpdateRecipe(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	var req RecipeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	recipe := req.toDomain()
+	recipe.ID = id
+
+	updated, err := h.svc.UpdateRecipe(r.Context(), userID, recipe)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRecipe(updated))
+}
+
+func (h *httpHandler) handleDeleteRecipe(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	if err := h.svc.DeleteRecipe(r.Context(), userID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *httpHandler) handleLogRecipe(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, domain.ErrNotFound)
+		return
+	}
+
+	h.handleLog(w, r, userID, id, h.svc.LogRecipe)
+}
+
+func (req RecipeRequest) toDomain() domain.Recipe {
+	recipe := domain.Recipe{
+		Name:        req.Name,
+		Description: req.Description,
+		Servings:    req.Servings,
+		Ingredients: make([]domain.RecipeIngredient, 0, len(req.Ingredients)),
+	}
+	for _, ingredient := range req.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, domain.RecipeIngredient{
+			FoodID:   ingredient.FoodID,
+			Grams:    ingredient.Grams,
+			Serving:  ingredient.Serving,
+			Quantity: ingredient.Quantity,
+		})
+	}
+
+	return recipe
+}
+
+func toRecipe(recipe *domain.Recipe) Recipe {
+	perServing, perServingMicros := recipe.PerServing(1)
+
+	response := Recipe{
+		ID:          recipe.ID.String(),
+		Name:        recipe.Name,
+		Description: recipe.Description,
+		Servings:    recipe.Servings,
+		Ingredients: make([]RecipeIngredient, 0, len(recipe.Ingredients)),
+		Total:       toRecipeNutrients(recipe.Nutrients, recipe.Micronutrients),
+		PerServing:  toRecipeNutrients(perServing, perServingMicros),
+		CreatedAt:   recipe.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   recipe.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, ingredient := range recipe.Ingredients {
+		response.Ingredients = append(response.Ingredients, RecipeIngredient{
+			FoodID:    ingredient.FoodID.String(),
+			FoodName:  ingredient.FoodName,
+			Grams:     ingredient.Grams,
+			Serving:   ingredient.Serving,
+			Quantity:  ingredient.Quantity,
+			Nutrients: toRecipeNutrients(ingredient.Nutrients, ingredient.Micronutrients),
+		})
+	}
+
+	return response
+}
+
+func toRecipeNutrients(n domain.Nutrients, m domain.Micronutrients) RecipeNutrients {
+	return RecipeNutrients{
+		Calories:     n.Calories,
+		Protein:      n.Protein,
+		Fat:          n.Fat,
+		Carbs:        n.Carbs,
+		Fiber:        m.Fiber,
+		Sugar:        m.Sugar,
+		SaturatedFat: m.SaturatedFat,
+		SodiumMg:     m.SodiumMg,
+	}
+}
@@ -0,0 +1,62 @@
+package dietapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type RestrictionsRequest struct {
+	Restrictions []string `json:"restrictions"`
+}
+
+type RestrictionsResponse struct {
+	Restrictions []string `json:"restrictions"`
+	// UpdatedAt is omitted until restrictions are first saved
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func (h *httpHandler) handleGetRestrictions(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	restrictions, err := h.svc.GetRestrictions(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRestrictionsResponse(restrictions))
+}
+
+func (h *httpHandler) handleUpdateRestrictions(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	var req RestrictionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	restrictions := make([]domain.Restriction, 0, len(req.Restrictions))
+	for _, restriction := range req.Restrictions {
+		restrictions = append(restrictions, domain.Restriction(restriction))
+	}
+
+	updated, err := h.svc.UpdateRestrictions(r.Context(), userID, restrictions)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRestrictionsResponse(updated))
+}
+
+func toRestrictionsResponse(restrictions *domain.UserRestrictions) RestrictionsResponse {
+	response := RestrictionsResponse{
+		Restrictions: toStrings(restrictions.Restrictions),
+	}
+	if !restrictions.UpdatedAt.IsZero() {
+		response.UpdatedAt = restrictions.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
@@ -0,0 +1,98 @@
+package dietapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type BodyProfile struct {
+	HeightCm      float64 `json:"height_cm"`
+	WeightKg      float64 `json:"weight_kg"`
+	Age           int     `json:"age"`
+	Sex           string  `json:"sex"`
+	ActivityLevel string  `json:"activity_level"`
+}
+
+type SettingsResponse struct {
+	Targets      Nutrients    `json:"targets"`
+	TargetSource string       `json:"target_source"`
+	Profile      *BodyProfile `json:"profile,omitempty"`
+	UpdatedAt    string       `json:"updated_at"`
+}
+
+// SettingsRequest sets targets manually, calculates them from profile, or
+// both, in which case the manual targets are kept
+type SettingsRequest struct {
+	Targets *Nutrients   `json:"targets"`
+	Profile *BodyProfile `json:"profile"`
+}
+
+func (h *httpHandler) handleGetSettings(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	settings, err := h.svc.GetSettings(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSettingsResponse(settings))
+}
+
+func (h *httpHandler) handleUpdateSettings(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	var req SettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	var opts dietsvc.UpdateSettingsOptions
+	if req.Targets != nil {
+		opts.Targets = &domain.Nutrients{
+			Calories: req.Targets.Calories,
+			Protein:  req.Targets.Protein,
+			Fat:      req.Targets.Fat,
+			Carbs:    req.Targets.Carbs,
+		}
+	}
+	if req.Profile != nil {
+		opts.Profile = &domain.BodyProfile{
+			HeightCm:      req.Profile.HeightCm,
+			WeightKg:      req.Profile.WeightKg,
+			Age:           req.Profile.Age,
+			Sex:           domain.Sex(req.Profile.Sex),
+			ActivityLevel: domain.ActivityLevel(req.Profile.ActivityLevel),
+		}
+	}
+
+	settings, err := h.svc.UpdateSettings(r.Context(), userID, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSettingsResponse(settings))
+}
+
+func toSettingsResponse(settings *domain.UserSettings) SettingsResponse {
+	response := SettingsResponse{
+		Targets:      toNutrients(settings.Targets),
+		TargetSource: string(settings.TargetSource),
+		UpdatedAt:    settings.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if p := settings.Profile; p != nil {
+		response.Profile = &BodyProfile{
+			HeightCm:      p.HeightCm,
+			WeightKg:      p.WeightKg,
+			Age:           p.Age,
+			Sex:           string(p.Sex),
+			ActivityLevel: string(p.ActivityLevel),
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,141 @@
+package dietapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+type Nutrients struct {
+	Calories float64 `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Fat      float64 `json:"fat"`
+	Carbs    float64 `json:"carbs"`
+}
+
+// Progress compares consumed nutrients with the user's targets. Remaining
+// goes negative once a target is exceeded.
+type Progress struct {
+	Target    Nutrients `json:"target"`
+	Remaining Nutrients `json:"remaining"`
+	Percent   Nutrients `json:"percent"`
+}
+
+type PeriodSummary struct {
+	Start         string    `json:"start"`
+	EntryCount    int       `json:"entry_count"`
+	DaysLogged    int       `json:"days_logged"`
+	Totals        Nutrients `json:"totals"`
+	DailyAverages Nutrients `json:"daily_averages"`
+	Progress      *Progress `json:"progress,omitempty"`
+}
+
+type SummaryResponse struct {
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	Granularity    string          `json:"granularity"`
+	TimeZone       string          `json:"time_zone"`
+	Totals         Nutrients       `json:"totals"`
+	PeriodAverages Nutrients       `json:"period_averages"`
+	Periods        []PeriodSummary `json:"periods"`
+	DailyTargets   *Nutrients      `json:"daily_targets,omitempty"`
+	Progress       *Progress       `json:"progress,omitempty"`
+}
+
+func (h *httpHandler) handleSummary(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	query := r.URL.Query()
+
+	tz := query.Get("tz")
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		writeError(w, domain.InvalidSummary("tz", "must be an IANA time zone name"))
+		return
+	}
+
+	opts := dietsvc.SummaryOptions{
+		Granularity: domain.Granularity(query.Get("granularity")),
+		Location:    loc,
+	}
+
+	// Default to the last 7 days, or the last 4 weeks when grouping by week
+	opts.To = time.Now().In(loc)
+	if to := query.Get("to"); to != "" {
+		if opts.To, err = time.ParseInLocation(dateLayout, to, loc); err != nil {
+			writeError(w, domain.InvalidSummary("to", "must be a YYYY-MM-DD date"))
+			return
+		}
+	}
+
+	opts.From = opts.To.AddDate(0, 0, -6)
+	if opts.Granularity == domain.GranularityWeek {
+		opts.From = opts.To.AddDate(0, 0, -27)
+	}
+	if from := query.Get("from"); from != "" {
+		if opts.From, err = time.ParseInLocation(dateLayout, from, loc); err != nil {
+			writeError(w, domain.InvalidSummary("from", "must be a YYYY-MM-DD date"))
+			return
+		}
+	}
+
+	summary, err := h.svc.Summary(r.Context(), userID, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := SummaryResponse{
+		From:           summary.From.Format(dateLayout),
+		To:             summary.To.Format(dateLayout),
+		Granularity:    string(summary.Granularity),
+		TimeZone:       summary.TimeZone,
+		Totals:         toNutrients(summary.Totals),
+		PeriodAverages: toNutrients(summary.PeriodAverages),
+		Periods:        make([]PeriodSummary, 0, len(summary.Periods)),
+		Progress:       toProgress(summary.Progress),
+	}
+	if summary.Targets != nil {
+		targets := toNutrients(*summary.Targets)
+		response.DailyTargets = &targets
+	}
+	for _, period := range summary.Periods {
+		response.Periods = append(response.Periods, PeriodSummary{
+			Start:         period.Start.Format(dateLayout),
+			EntryCount:    period.EntryCount,
+			DaysLogged:    period.DaysLogged,
+			Totals:        toNutrients(period.Totals),
+			DailyAverages: toNutrients(period.DailyAverages),
+			Progress:      toProgress(period.Progress),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func toNutrients(n domain.Nutrients) Nutrients {
+	return Nutrients{
+		Calories: n.Calories,
+		Protein:  n.Protein,
+		Fat:      n.Fat,
+		Carbs:    n.Carbs,
+	}
+}
+
+func toProgress(p *domain.Progress) *Progress {
+	if p == nil {
+		return nil
+	}
+
+	return &Progress{
+		Target:    toNutrients(p.Target),
+		Remaining: toNutrients(p.Remaining),
+		Percent:   toNutrients(p.Percent),
+	}
+}
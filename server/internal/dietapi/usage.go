@@ -0,0 +1,47 @@
+package dietapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc"
+)
+
+type UsageResponse struct {
+	Daily   QuotaUsage `json:"daily"`
+	Monthly QuotaUsage `json:"monthly"`
+}
+
+// QuotaUsage counts uncached analyses; Limit and Remaining are null when the
+// period is unlimited
+type QuotaUsage struct {
+	Limit     *int    `json:"limit"`
+	Used      int     `json:"used"`
+	Remaining *int    `json:"remaining"`
+	CostUSD   float64 `json:"cost_usd"`
+	ResetsAt  string  `json:"resets_at"`
+}
+
+func (h *httpHandler) handleGetUsage(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	report, err := h.svc.GetUsage(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, UsageResponse{
+		Daily:   toQuotaUsage(report.Daily),
+		Monthly: toQuotaUsage(report.Monthly),
+	})
+}
+
+func toQuotaUsage(usage dietsvc.QuotaUsage) QuotaUsage {
+	return QuotaUsage{
+		Limit:     usage.Limit,
+		Used:      usage.Used,
+		Remaining: usage.Remaining,
+		CostUSD:   usage.CostUSD,
+		ResetsAt:  usage.ResetsAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,48 @@
+package dietsvc
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	MaxBatchImages          = 20
+	defaultBatchConcurrency = 4
+)
+
+// BatchResult is the outcome for one image of a batch; exactly one of Result
+// and Err is set
+type BatchResult struct {
+	Result *AnalyzeFoodResult
+	Err    error
+}
+
+// AnalyzeFoodBatch analyzes several images concurrently, at most
+// batchConcurrency at a time. One image failing does not affect the others;
+// results are returned in input order.
+func (s *Service) AnalyzeFoodBatch(ctx context.Context, images []AnalyzeFoodOptions) ([]BatchResult, error) {
+	if len(images) == 0 {
+		return nil, domain.ErrNoImageProvided
+	}
+	if len(images) > MaxBatchImages {
+		return nil, domain.ErrBatchTooLarge
+	}
+
+	results := make([]BatchResult, len(images))
+
+	var g errgroup.Group
+	g.SetLimit(s.batchConcurrency)
+	for i, opts := range images {
+		g.Go(func() error {
+			// Each goroutine writes only its own slot
+			results[i].Result, results[i].Err = s.AnalyzeFood(ctx, opts)
+			return nil
+		})
+	}
+	g.Wait()
+
+	return results, nil
+}
@@ -0,0 +1,143 @@
+package dietsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	defaultAccuracyMinSamples = 3
+	defaultAccuracyLimit      = 50
+	maxAccuracyLimit          = 200
+	maxAccuracyDays           = 366
+)
+
+// CorrectAnalysis stores the user's corrected values for an analysis they
+// were served. Correcting again replaces the previous correction.
+func (s *Service) CorrectAnalysis(ctx context.Context, userID, id uuid.UUID, correction domain.Correction) (*domain.StoredAnalysis, error) {
+	if correction.IsEmpty() {
+		return nil, domain.InvalidCorrection("calories", "at least one of calories, protein, fat or carbs is required")
+	}
+
+	for _, value := range []struct {
+		field string
+		value *float64
+		max   float64
+	}{
+		{"calories", correction.Calories, maxEntryCalories},
+		{"protein", correction.Protein, maxEntryMacroGrams},
+		{"fat", correction.Fat, maxEntryMacroGrams},
+		{"carbs", correction.Carbs, maxEntryMacroGrams},
+	} {
+		if value.value != nil && !isValidAmount(*value.value, value.max) {
+			return nil, domain.InvalidCorrection(value.field, fmt.Sprintf("must be between 0 and %.0f", value.max))
+		}
+	}
+
+	analysis, err := s.analysisRepo.Correct(ctx, userID, id, correction)
+	if err != nil {
+		return nil, domain.WrapError("failed to correct analysis", err)
+	}
+
+	return analysis, nil
+}
+
+// AccuracyReportOptions selects analyses created on UTC dates From through
+// To inclusive
+type AccuracyReportOptions struct {
+	From       time.Time
+	To         time.Time
+	MinSamples int
+	Limit      int
+}
+
+// AccuracyReport measures analyzer accuracy against user corrections as the
+// mean absolute error per prompt version and per food item and prompt version
+func (s *Service) AccuracyReport(ctx context.Context, opts AccuracyReportOptions) (*domain.AccuracyReport, error) {
+	from := time.Date(opts.From.Year(), opts.From.Month(), opts.From.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(opts.To.Year(), opts.To.Month(), opts.To.Day()+1, 0, 0, 0, 0, time.UTC)
+	if !from.Before(to) {
+		return nil, domain.InvalidAccuracyQuery("from", "must not be after to")
+	}
+	if to.Sub(from) > maxAccuracyDays*24*time.Hour {
+		return nil, domain.InvalidAccuracyQuery("to", "range must not exceed 366 days")
+	}
+
+	minSamples := opts.MinSamples
+	if minSamples <= 0 {
+		minSamples = defaultAccuracyMinSamples
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultAccuracyLimit
+	}
+	limit = min(limit, maxAccuracyLimit)
+
+	report, err := s.analysisRepo.AccuracyReport(ctx, domain.AccuracyQuery{
+		From:       from,
+		To:         to,
+		MinSamples: minSamples,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, domain.WrapError("failed to build accuracy report", err)
+	}
+
+	return report, nil
+}
+
+// storeAnalysis keeps an analysis served to the user and sets its ID so it
+// can be corrected later. Cached analyses are stored too, so they can be
+// corrected, but are left out of accuracy reports. A failure is logged and
+// leaves the ID unset rather than failing the analysis.
+func (s *Service) storeAnalysis(ctx context.Context, userID uuid.UUID, kind domain.JobKind, cached bool, analysis *domain.DietAnalysis) {
+	items := make([]domain.ItemEstimate, 0, len(analysis.Items))
+	for _, item := range analysis.Items {
+		items = append(items, domain.ItemEstimate{
+			Name: normalizeItemName(item.Name),
+			Nutrients: domain.Nutrients{
+				Calories: item.Calories,
+				Protein:  item.Protein,
+				Fat:      item.Fat,
+				Carbs:    item.Carbs,
+			},
+		})
+	}
+
+	stored, err := s.analysisRepo.Create(ctx, domain.StoredAnalysis{
+		UserID:   userID,
+		Kind:     kind,
+		FoodName: analysis.FoodName,
+		Original: domain.Nutrients{
+			Calories: analysis.Calories,
+			Protein:  analysis.Protein,
+			Fat:      analysis.Fat,
+			Carbs:    analysis.Carbs,
+		},
+		Items:         items,
+		Confidence:    analysis.Confidence,
+		Model:         analysis.Model,
+		PromptVersion: analysis.PromptVersion,
+		Cached:        cached,
+	})
+	if err != nil {
+		slog.Error("failed to store analysis", "user_id", userID, "error", err)
+		return
+	}
+
+	analysis.ID = stored.ID
+}
+
+// normalizeItemName lowercases an item name and collapses its whitespace, so
+// accuracy groups the same food named slightly differently
+func normalizeItemName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -1,9 +1,311 @@
 package domain
 
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxMealCalories = 10000
+	maxItemCalories = 5000
+	maxMacroGrams   = 1000
+	maxPortionGrams = 5000
+	maxSodiumMg     = 50000
+
+	// LowConfidenceThreshold is the confidence below which the user should be
+	// asked to confirm the analysis
+	LowConfidenceThreshold = 0.6
+
+	// Calories from macros (4/4/9 kcal per gram) must be within this fraction
+	// of the reported total, or within minCalorieSlack kcal for small meals
+	calorieTolerance = 0.35
+	minCalorieSlack  = 75
+)
+
 type DietAnalysis struct {
-	FoodName string
-	Calories float64
-	Protein  float64
-	Fat      float64
-	Carbs    float64
+	// ID identifies an analysis served to a user, for corrections; it is
+	// zero for analyses that are not stored, such as label lookups
+	ID         uuid.UUID
+	FoodName   string
+	Items      []FoodItem
+	Calories   float64
+	Protein    float64
+	Fat        float64
+	Carbs      float64
+	IsFood     bool
+	Confidence float64
+	// MealType is the meal the analyzer classified the food as, or empty
+	// when it did not; the service then resolves it with the user's choice
+	// and local time
+	MealType MealType
+	// Warnings flag items that conflict with the user's restrictions; they
+	// are added per user and not cached. RestrictionsChecked is false when
+	// the restrictions could not be checked, so no Warnings does not mean no
+	// conflicts.
+	Warnings            []RestrictionWarning
+	RestrictionsChecked bool
+	Micronutrients
+	Provenance
+	// Usage is reported by the analyzer for accounting and is not stored
+	// with cached analyses
+	Usage TokenUsage `json:"-"`
+}
+
+// FoodItem is a single food detected in a meal with its own portion and macros
+type FoodItem struct {
+	Name         string
+	PortionGrams float64
+	Servings     float64
+	Calories     float64
+	Protein      float64
+	Fat          float64
+	Carbs        float64
+	// Ingredients are the main and likely hidden ingredients, and Allergens
+	// and Diet are derived from them. They are estimates, empty when the
+	// analyzer does not report them.
+	Ingredients []string
+	Allergens   []Allergen
+	Diet        Diet
+	Micronutrients
+}
+
+// Micronutrients are optional estimates in grams, except sodium in
+// milligrams. A nil value means the amount is unknown, not zero.
+type Micronutrients struct {
+	Fiber        *float64
+	Sugar        *float64
+	SaturatedFat *float64
+	SodiumMg     *float64
+}
+
+// SumItems sets the analysis totals to the sum of its items. It is a no-op
+// when the analysis has no items. A micronutrient total is only known when
+// every item reports it.
+func (a *DietAnalysis) SumItems() {
+	if len(a.Items) == 0 {
+		return
+	}
+
+	a.Calories, a.Protein, a.Fat, a.Carbs = 0, 0, 0, 0
+	a.Micronutrients = Micronutrients{
+		Fiber:        new(float64),
+		Sugar:        new(float64),
+		SaturatedFat: new(float64),
+		SodiumMg:     new(float64),
+	}
+	for _, item := range a.Items {
+		a.Calories += item.Calories
+		a.Protein += item.Protein
+		a.Fat += item.Fat
+		a.Carbs += item.Carbs
+
+		a.Fiber = addKnown(a.Fiber, item.Fiber)
+		a.Sugar = addKnown(a.Sugar, item.Sugar)
+		a.SaturatedFat = addKnown(a.SaturatedFat, item.SaturatedFat)
+		a.SodiumMg = addKnown(a.SodiumMg, item.SodiumMg)
+	}
+}
+
+// addKnown returns a+b, or nil when either amount is unknown
+func addKnown(a, b *float64) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	sum := *a + *b
+	return &sum
+}
+
+// Scale multiplies the known micronutrients by factor
+func (m Micronutrients) Scale(factor float64) Micronutrients {
+	return Micronutrients{
+		Fiber:        scaleKnown(m.Fiber, factor),
+		Sugar:        scaleKnown(m.Sugar, factor),
+		SaturatedFat: scaleKnown(m.SaturatedFat, factor),
+		SodiumMg:     scaleKnown(m.SodiumMg, factor),
+	}
+}
+
+// Allergens returns the allergens found in any item, in Allergens order
+func (a *DietAnalysis) Allergens() []Allergen {
+	var found []Allergen
+	for _, allergen := range Allergens {
+		for _, item := range a.Items {
+			if slices.Contains(item.Allergens, allergen) {
+				found = append(found, allergen)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// IsLowConfidence reports whether the analysis should be confirmed by the user
+func (a *DietAnalysis) IsLowConfidence() bool {
+	return a.Confidence < LowConfidenceThreshold
+}
+
+// Validate rejects analyses with missing, negative or implausible values and
+// returns an AnalysisFailed error describing the first problem found. Non-food
+// results only need a valid confidence.
+func (a *DietAnalysis) Validate() error {
+	if math.IsNaN(a.Confidence) || a.Confidence < 0 || a.Confidence > 1 {
+		return AnalysisFailed("confidence must be between 0 and 1")
+	}
+	if !a.IsFood {
+		return nil
+	}
+
+	if a.FoodName == "" {
+		return AnalysisFailed("food name is empty")
+	}
+	if len(a.Items) == 0 {
+		return AnalysisFailed("no food items detected")
+	}
+
+	for _, item := range a.Items {
+		if item.Name == "" {
+			return AnalysisFailed("food item name is empty")
+		}
+		if err := validateAmount(item.Name+" portion", item.PortionGrams, maxPortionGrams); err != nil {
+			return err
+		}
+		if item.Servings < 0 {
+			return AnalysisFailed(fmt.Sprintf("%s servings is negative", item.Name))
+		}
+		if err := validateAmount(item.Name+" calories", item.Calories, maxItemCalories); err != nil {
+			return err
+		}
+		if err := validateMacros(item.Name, item.Protein, item.Fat, item.Carbs); err != nil {
+			return err
+		}
+		if err := item.Micronutrients.validate(item.Name, item.Fat, item.Carbs); err != nil {
+			return err
+		}
+	}
+
+	if err := validateAmount("total calories", a.Calories, maxMealCalories); err != nil {
+		return err
+	}
+	if err := validateMacros("meal", a.Protein, a.Fat, a.Carbs); err != nil {
+		return err
+	}
+	if err := a.Micronutrients.validate("meal", a.Fat, a.Carbs); err != nil {
+		return err
+	}
+
+	macroCalories := 4*a.Protein + 4*a.Carbs + 9*a.Fat
+	slack := math.Max(a.Calories*calorieTolerance, minCalorieSlack)
+	if math.Abs(macroCalories-a.Calories) > slack {
+		return AnalysisFailed(fmt.Sprintf(
+			"macros add up to %.0f kcal but total is %.0f kcal", macroCalories, a.Calories,
+		))
+	}
+
+	return nil
+}
+
+func validateMacros(name string, protein, fat, carbs float64) error {
+	if err := validateAmount(name+" protein", protein, maxMacroGrams); err != nil {
+		return err
+	}
+	if err := validateAmount(name+" fat", fat, maxMacroGrams); err != nil {
+		return err
+	}
+	return validateAmount(name+" carbs", carbs, maxMacroGrams)
+}
+
+// validate checks the known micronutrients are in range and that sugar and
+// saturated fat do not exceed the carbs and fat they are part of
+func (m Micronutrients) validate(name string, fat, carbs float64) error {
+	for _, amount := range []struct {
+		label string
+		value *float64
+		max   float64
+	}{
+		{"fiber", m.Fiber, maxMacroGrams},
+		{"sugar", m.Sugar, maxMacroGrams},
+		{"saturated fat", m.SaturatedFat, maxMacroGrams},
+		{"sodium", m.SodiumMg, maxSodiumMg},
+	} {
+		if amount.value == nil {
+			continue
+		}
+		if err := validateAmount(name+" "+amount.label, *amount.value, amount.max); err != nil {
+			return err
+		}
+	}
+
+	// Allow a gram of rounding slack
+	if m.Sugar != nil && *m.Sugar > carbs+1 {
+		return AnalysisFailed(fmt.Sprintf("%s sugar exceeds its carbs", name))
+	}
+	if m.SaturatedFat != nil && *m.SaturatedFat > fat+1 {
+		return AnalysisFailed(fmt.Sprintf("%s saturated fat exceeds its fat", name))
+	}
+
+	return nil
+}
+
+func validateAmount(name string, value, max float64) error {
+	if reason := checkAmount(name, value, max); reason != "" {
+		return AnalysisFailed(reason)
+	}
+	return nil
+}
+
+// checkAmount returns why an estimated amount is invalid, or "" when it is
+// a number between 0 and max
+func checkAmount(name string, value, max float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Sprintf("%s is not a number", name)
+	}
+	if value < 0 {
+		return fmt.Sprintf("%s is negative", name)
+	}
+	if value > max {
+		return fmt.Sprintf("%s of %.0f exceeds limit of %.0f", name, value, max)
+	}
+	return ""
+}
+
+// AnalysisHints is optional user-provided context that helps the analyzer
+// estimate portions and identify dishes
+type AnalysisHints struct {
+	PortionSize string
+	Servings    float64
+	Cuisine     string
+	MealType    MealType
+	Notes       string
+	// LocalTime is when the meal was eaten, with the user's UTC offset. It
+	// only informs the resolved meal type and is not sent to the analyzer.
+	LocalTime *time.Time
+}
+
+// IsEmpty reports whether there is any hint for the analyzer
+func (h AnalysisHints) IsEmpty() bool {
+	h.LocalTime = nil
+	return h == AnalysisHints{}
+}
+
+// Provenance identifies what produced an analysis, so estimates can be
+// compared across prompt versions and traced when they change. Both fields
+// are empty for analyses not produced by a model, such as label lookups.
+type Provenance struct {
+	Model         string
+	PromptVersion string
+}
+
+// FoodAnalyzer errors carry, via WithUsage, the usage of any provider calls
+// that were paid for before the analysis failed
+type FoodAnalyzer interface {
+	AnalyzeFood(ctx context.Context, imageData []byte, mimeType string, hints AnalysisHints) (*DietAnalysis, error)
+	AnalyzeFoodText(ctx context.Context, description string) (*DietAnalysis, error)
+	// Provenance is the configured model and prompt version new analyses
+	// are produced with
+	Provenance() Provenance
 }
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AnalysisCache stores analyses keyed by a hash of the normalized input. Get
+// returns ErrNotFound for missing or expired entries.
+type AnalysisCache interface {
+	Get(ctx context.Context, key string) (*DietAnalysis, error)
+	Set(ctx context.Context, key string, analysis DietAnalysis, expiresAt time.Time) error
+	DeleteExpired(ctx context.Context) error
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoredAnalysis is an analysis served to a user, kept so the user can later
+// correct it. Original holds the model's estimate and Items its per-item
+// estimates. Cached analyses were served from the cache, repeating an
+// earlier estimate.
+type StoredAnalysis struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Kind          JobKind
+	FoodName      string
+	Original      Nutrients
+	Items         []ItemEstimate
+	Confidence    float64
+	Model         string
+	PromptVersion string
+	Cached        bool
+	Correction    *Correction
+	CreatedAt     time.Time
+}
+
+// ItemEstimate is the estimate for one item of a stored analysis. Name is
+// normalized so the same food groups together across analyses.
+type ItemEstimate struct {
+	Name string
+	Nutrients
+}
+
+// Correction is the user's fix to an analysis. A nil value was not
+// corrected and is left out of the error for that nutrient.
+type Correction struct {
+	Calories    *float64
+	Protein     *float64
+	Fat         *float64
+	Carbs       *float64
+	CorrectedAt time.Time
+}
+
+func (c Correction) IsEmpty() bool {
+	return c.Calories == nil && c.Protein == nil && c.Fat == nil && c.Carbs == nil
+}
+
+// NutrientErrors are mean absolute errors between estimates and
+// corrections. A nil value means no correction covered the nutrient.
+type NutrientErrors struct {
+	Calories *float64
+	Protein  *float64
+	Fat      *float64
+	Carbs    *float64
+}
+
+// AccuracyGroup is the error of corrected analyses sharing a prompt version
+// and, for per-food groups, an item name. An item's error is the meal's error
+// in proportion to the item's share of the estimate. Cached analyses are left
+// out as repeats.
+type AccuracyGroup struct {
+	FoodName      string
+	PromptVersion string
+	Samples       int
+	MAE           NutrientErrors
+}
+
+// AccuracyQuery selects corrected analyses created in [From, To). Groups
+// with fewer than MinSamples corrections are left out.
+type AccuracyQuery struct {
+	From       time.Time
+	To         time.Time
+	MinSamples int
+	Limit      int
+}
+
+type AccuracyReport struct {
+	PromptVersions []AccuracyGroup
+	// Foods are ordered by sample count, largest first
+	Foods []AccuracyGroup
+}
+
+type AnalysisRepository interface {
+	Create(ctx context.Context, analysis StoredAnalysis) (*StoredAnalysis, error)
+	// Get returns ErrNotFound when the user has no analysis with the ID
+	Get(ctx context.Context, userID, id uuid.UUID) (*StoredAnalysis, error)
+	// Correct replaces the correction of the user's analysis. It returns
+	// ErrNotFound when the user has no analysis with the ID.
+	Correct(ctx context.Context, userID, id uuid.UUID, correction Correction) (*StoredAnalysis, error)
+	AccuracyReport(ctx context.Context, query AccuracyQuery) (*AccuracyReport, error)
+}
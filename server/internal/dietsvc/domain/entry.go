@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EntrySource string
+
+const (
+	EntrySourceManual EntrySource = "manual"
+	EntrySourceImage  EntrySource = "image"
+	EntrySourceText   EntrySource = "text"
+	EntrySourceRecipe EntrySource = "recipe"
+)
+
+func (s EntrySource) IsValid() bool {
+	switch s {
+	case EntrySourceManual, EntrySourceImage, EntrySourceText, EntrySourceRecipe:
+		return true
+	}
+	return false
+}
+
+type DietEntry struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	Calories    float64
+	Protein     float64
+	Fat         float64
+	Carbs       float64
+	EatenAt     time.Time
+	ImageRef    string
+	Source      EntrySource
+	// MealType is empty for entries logged before meal types were recorded
+	MealType   MealType
+	Confidence *float64
+	// AnalysisID is the analysis the values came from, nil for manual and
+	// copied entries. Provenance is taken from that analysis.
+	AnalysisID *uuid.UUID
+	Provenance
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Micronutrients
+}
+
+// EntryCursor identifies the last entry of a page in eaten_at, id order
+type EntryCursor struct {
+	EatenAt time.Time
+	ID      uuid.UUID
+}
+
+type EntryFilter struct {
+	From   *time.Time
+	To     *time.Time
+	Cursor *EntryCursor
+	Limit  int
+}
+
+type EntryRepository interface {
+	Create(ctx context.Context, entry DietEntry) (*DietEntry, error)
+	Get(ctx context.Context, userID, id uuid.UUID) (*DietEntry, error)
+	List(ctx context.Context, userID uuid.UUID, filter EntryFilter) ([]DietEntry, error)
+	Update(ctx context.Context, entry DietEntry) (*DietEntry, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Summarize(ctx context.Context, query SummaryQuery) ([]PeriodSummary, error)
+	// ListRecent returns the latest entry of each name eaten since the given
+	// time, names compared case-insensitively
+	ListRecent(ctx context.Context, userID uuid.UUID, since time.Time) ([]RecentFood, error)
+}
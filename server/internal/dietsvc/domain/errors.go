@@ -1,21 +1,184 @@
 package domain
 
-import "github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+import (
+	"errors"
+
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
 
 var (
-	ErrImageTooLarge   = httperrors.New(400, "IMAGE_TOO_LARGE", "image size must not exceed 5MB")
-	ErrInvalidImage    = httperrors.New(400, "INVALID_IMAGE", "image format not supported, please upload JPEG, PNG, or WebP")
-	ErrAnalysisFailed  = httperrors.New(500, "ANALYSIS_FAILED", "failed to analyze food image")
-	ErrNoImageProvided = httperrors.New(400, "NO_IMAGE_PROVIDED", "no image file provided")
+	ErrNotFound            = httperrors.New(404, "NOT_FOUND", "resource not found")
+	ErrImageTooLarge       = httperrors.New(400, "IMAGE_TOO_LARGE", "image size must not exceed 5MB")
+	ErrInvalidImage        = httperrors.New(400, "INVALID_IMAGE", "image format not supported, please upload JPEG, PNG, or WebP")
+	ErrAnalysisFailed      = httperrors.New(500, "ANALYSIS_FAILED", "failed to analyze food image")
+	ErrNoImageProvided     = httperrors.New(400, "NO_IMAGE_PROVIDED", "no image file provided")
+	ErrNotFood             = httperrors.New(422, "NOT_FOOD", "no food detected")
+	ErrNoDescription       = httperrors.New(400, "NO_DESCRIPTION_PROVIDED", "no meal description provided")
+	ErrDescriptionTooLong  = httperrors.New(400, "DESCRIPTION_TOO_LONG", "meal description must not exceed 1000 characters")
+	ErrInvalidHints        = httperrors.New(400, "INVALID_HINTS", "invalid analysis hints")
+	ErrInvalidEntry        = httperrors.New(400, "INVALID_ENTRY", "invalid diet entry")
+	ErrInvalidCursor       = httperrors.New(400, "INVALID_CURSOR", "invalid pagination cursor")
+	ErrInvalidSummary      = httperrors.New(400, "INVALID_SUMMARY_QUERY", "invalid summary query")
+	ErrInvalidSettings     = httperrors.New(400, "INVALID_SETTINGS", "invalid nutrition settings")
+	ErrInvalidFoodQuery    = httperrors.New(400, "INVALID_FOOD_QUERY", "food search query must be between 2 and 100 characters")
+	ErrInvalidBarcode      = httperrors.New(400, "INVALID_BARCODE", "barcode must be a valid EAN-13, EAN-8, UPC-A or GTIN-14 code")
+	ErrProductNotFound     = httperrors.New(404, "PRODUCT_NOT_FOUND", "no product found for barcode")
+	ErrInvalidCallbackURL  = httperrors.New(400, "INVALID_CALLBACK_URL", "callback_url must be an absolute http or https URL")
+	ErrBatchTooLarge       = httperrors.New(400, "BATCH_TOO_LARGE", "batch must not exceed 20 images")
+	ErrQuotaExceeded       = httperrors.New(429, "QUOTA_EXCEEDED", "analysis quota exceeded")
+	ErrServiceUnavailable  = httperrors.New(503, "SERVICE_UNAVAILABLE", "food analysis is temporarily unavailable")
+	ErrInvalidCorrection   = httperrors.New(400, "INVALID_CORRECTION", "invalid analysis correction")
+	ErrInvalidAccuracy     = httperrors.New(400, "INVALID_ACCURACY_QUERY", "invalid accuracy report query")
+	ErrInvalidRestrictions = httperrors.New(400, "INVALID_RESTRICTIONS", "invalid dietary restrictions")
+	ErrInvalidRecipe       = httperrors.New(400, "INVALID_RECIPE", "invalid recipe")
+	ErrPlanFailed          = httperrors.New(500, "MEAL_PLAN_FAILED", "failed to generate meal plan")
+	ErrInvalidMealPlan     = httperrors.New(400, "INVALID_MEAL_PLAN", "invalid meal plan request")
+	ErrTargetsRequired     = httperrors.New(422, "TARGETS_REQUIRED", "set daily nutrition targets before generating a meal plan")
 )
 
+// AnalysisFailed returns an ErrAnalysisFailed variant carrying the reason the
+// analysis was rejected. It still matches ErrAnalysisFailed with errors.Is.
+func AnalysisFailed(reason string) error {
+	return httperrors.New(
+		ErrAnalysisFailed.HttpStatus,
+		ErrAnalysisFailed.Code,
+		ErrAnalysisFailed.Message+": "+reason,
+	)
+}
+
+// PlanFailed returns an ErrPlanFailed variant carrying the reason the
+// generated plan was rejected. It still matches ErrPlanFailed with errors.Is.
+func PlanFailed(reason string) error {
+	return httperrors.New(
+		ErrPlanFailed.HttpStatus,
+		ErrPlanFailed.Code,
+		ErrPlanFailed.Message+": "+reason,
+	)
+}
+
+// InvalidHints returns an ErrInvalidHints variant naming the offending field
+func InvalidHints(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidHints.HttpStatus,
+		ErrInvalidHints.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidEntry returns an ErrInvalidEntry variant naming the offending field
+func InvalidEntry(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidEntry.HttpStatus,
+		ErrInvalidEntry.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidRecipe returns an ErrInvalidRecipe variant naming the offending
+// field
+func InvalidRecipe(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidRecipe.HttpStatus,
+		ErrInvalidRecipe.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidMealPlan returns an ErrInvalidMealPlan variant naming the
+// offending field
+func InvalidMealPlan(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidMealPlan.HttpStatus,
+		ErrInvalidMealPlan.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidSummary returns an ErrInvalidSummary variant naming the offending
+// query parameter
+func InvalidSummary(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidSummary.HttpStatus,
+		ErrInvalidSummary.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidSettings returns an ErrInvalidSettings variant naming the offending
+// field
+func InvalidSettings(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidSettings.HttpStatus,
+		ErrInvalidSettings.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidCorrection returns an ErrInvalidCorrection variant naming the
+// offending field
+func InvalidCorrection(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidCorrection.HttpStatus,
+		ErrInvalidCorrection.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// InvalidAccuracyQuery returns an ErrInvalidAccuracy variant naming the
+// offending query parameter
+func InvalidAccuracyQuery(field, reason string) error {
+	return httperrors.New(
+		ErrInvalidAccuracy.HttpStatus,
+		ErrInvalidAccuracy.Code,
+		field+" "+reason,
+		field,
+	)
+}
+
+// QuotaExceeded returns an ErrQuotaExceeded variant naming the limit reached
+func QuotaExceeded(reason string) error {
+	return httperrors.New(
+		ErrQuotaExceeded.HttpStatus,
+		ErrQuotaExceeded.Code,
+		ErrQuotaExceeded.Message+": "+reason,
+	)
+}
+
+// ServiceUnavailable returns an ErrServiceUnavailable variant with the reason
+func ServiceUnavailable(reason string) error {
+	return httperrors.New(
+		ErrServiceUnavailable.HttpStatus,
+		ErrServiceUnavailable.Code,
+		ErrServiceUnavailable.Message+": "+reason,
+	)
+}
+
+// InvalidRestrictions returns an ErrInvalidRestrictions variant with the
+// reason
+func InvalidRestrictions(reason string) error {
+	return httperrors.New(
+		ErrInvalidRestrictions.HttpStatus,
+		ErrInvalidRestrictions.Code,
+		"restrictions "+reason,
+		"restrictions",
+	)
+}
+
 func WrapError(msg string, err error) error {
 	if err == nil {
 		return nil
 	}
 
-	if _, ok := err.(*httperrors.Error); ok {
-		return err
+	var httpErr httperrors.Error
+	if errors.As(err, &httpErr) {
+		return httpErr
 	}
 
 	return httperrors.New(500, "INTERNAL_ERROR", msg+": "+err.Error())
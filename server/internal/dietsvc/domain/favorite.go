@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Favorite is a food the user saved for relogging. Its values are copied
+// from an entry, so it outlives that entry. A user has one favorite per name.
+type Favorite struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	Nutrients
+	Micronutrients
+	// Source and Confidence describe how the copied values were obtained
+	Source     EntrySource
+	Confidence *float64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// RecentFood is a food the user logged recently. Entry is its latest entry
+// and LogCount counts the entries with the same name.
+type RecentFood struct {
+	Entry    DietEntry
+	LogCount int
+}
+
+// RecentsOrder orders recent foods by their last entry or by how often they
+// were logged
+type RecentsOrder string
+
+const (
+	RecentsOrderRecent   RecentsOrder = "recent"
+	RecentsOrderFrequent RecentsOrder = "frequent"
+)
+
+func (o RecentsOrder) IsValid() bool {
+	return o == RecentsOrderRecent || o == RecentsOrderFrequent
+}
+
+type FavoriteRepository interface {
+	// Upsert replaces the values of a favorite with the same name
+	Upsert(ctx context.Context, favorite Favorite) (*Favorite, error)
+	Get(ctx context.Context, userID, id uuid.UUID) (*Favorite, error)
+	// List returns the user's favorites by name
+	List(ctx context.Context, userID uuid.UUID) ([]Favorite, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
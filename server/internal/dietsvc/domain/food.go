@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Food is a reference food from a composition dataset. Nutrients and
+// Micronutrients are per 100 g.
+type Food struct {
+	ID       uuid.UUID
+	Source   string
+	SourceID string
+	Name     string
+	Category string
+	Nutrients
+	Micronutrients
+	Servings []Serving
+}
+
+// Serving is a common household measure of a food, such as "1 cup"
+type Serving struct {
+	Label string
+	Grams float64
+}
+
+type FoodRepository interface {
+	// Get returns ErrNotFound when there is no food with the ID
+	Get(ctx context.Context, id uuid.UUID) (*Food, error)
+	// Search returns foods whose names fuzzily match query, best match first
+	Search(ctx context.Context, query string, limit int) ([]Food, error)
+	// Upsert inserts a food or replaces the one with the same source and
+	// source ID
+	Upsert(ctx context.Context, food Food) error
+}
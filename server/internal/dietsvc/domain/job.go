@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobKind string
+
+const (
+	JobKindImage JobKind = "image"
+	JobKindText  JobKind = "text"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+func (s JobStatus) IsFinal() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed
+}
+
+// AnalysisJob is a queued image or text analysis. Image holds the normalized
+// image until the job completes.
+type AnalysisJob struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Kind        JobKind
+	Status      JobStatus
+	Image       []byte
+	Description string
+	Hints       AnalysisHints
+	BypassCache bool
+	CallbackURL string
+	Result      *DietAnalysis
+	Cached      bool
+	Error       *JobError
+	Attempts    int
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// JobError is the API error a failed job would have returned synchronously
+type JobError struct {
+	Code    string
+	Message string
+}
+
+type JobRepository interface {
+	Create(ctx context.Context, job AnalysisJob) (*AnalysisJob, error)
+	// Get returns ErrNotFound when the user has no job with the ID
+	Get(ctx context.Context, userID, id uuid.UUID) (*AnalysisJob, error)
+	// ClaimNext marks the oldest available pending job, or a running job
+	// started before staleBefore, as running and returns it. It returns
+	// ErrNotFound when there is nothing to run.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*AnalysisJob, error)
+	// Complete stores the job's final status, result and error. It returns
+	// ErrNotFound when the job is no longer running under the claim that
+	// job.Attempts counts, such as after another worker reclaimed it.
+	Complete(ctx context.Context, job AnalysisJob) (*AnalysisJob, error)
+	// Release returns a job still running under its claim to the queue, to
+	// be claimed again from availableAt
+	Release(ctx context.Context, job AnalysisJob, availableAt time.Time) error
+	DeleteCompletedBefore(ctx context.Context, before time.Time) error
+}
+
+// JobEvent is sent to a job's callback URL once it completes
+type JobEvent struct {
+	JobID       uuid.UUID
+	Status      JobStatus
+	CompletedAt time.Time
+}
+
+type JobNotifier interface {
+	Notify(ctx context.Context, callbackURL string, event JobEvent) error
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+type MealType string
+
+const (
+	MealTypeBreakfast MealType = "breakfast"
+	MealTypeLunch     MealType = "lunch"
+	MealTypeDinner    MealType = "dinner"
+	MealTypeSnack     MealType = "snack"
+)
+
+// MealTypes lists the meal types in the order meals are eaten
+var MealTypes = []MealType{MealTypeBreakfast, MealTypeLunch, MealTypeDinner, MealTypeSnack}
+
+func (m MealType) IsValid() bool {
+	switch m {
+	case MealTypeBreakfast, MealTypeLunch, MealTypeDinner, MealTypeSnack:
+		return true
+	}
+	return false
+}
+
+// MealTypeAt infers the meal from the clock time of t in its own location,
+// so t must carry the user's zone or UTC offset. Food outside the usual
+// meal windows is a snack.
+func MealTypeAt(t time.Time) MealType {
+	switch hour := t.Hour(); {
+	case hour >= 5 && hour < 11:
+		return MealTypeBreakfast
+	case hour >= 11 && hour < 16:
+		return MealTypeLunch
+	case hour >= 17 && hour < 22:
+		return MealTypeDinner
+	}
+	return MealTypeSnack
+}
+
+// ResolveMealType picks the meal type of an analysis. An explicit choice
+// wins. Otherwise the local time decides which meal it is and the detected
+// type, classified from the food itself, decides whether it is a meal at
+// all: a detected snack stays a snack at any hour, and a detected meal
+// outside the meal windows keeps its detected type. Without a local time
+// the detected type is used, which may be empty.
+func ResolveMealType(explicit, detected MealType, localTime *time.Time) MealType {
+	if explicit != "" {
+		return explicit
+	}
+	if localTime == nil || detected == MealTypeSnack {
+		return detected
+	}
+
+	byClock := MealTypeAt(*localTime)
+	if byClock == MealTypeSnack && detected != "" {
+		return detected
+	}
+	return byClock
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MealPlanDays is the number of days a new meal plan covers
+	MealPlanDays = 7
+	// mealPlanCalorieTolerance is how far a planned day may stray from the
+	// calorie target
+	mealPlanCalorieTolerance = 0.2
+)
+
+// PlannedMeal is one meal of a planned day; Nutrients are for the whole meal
+type PlannedMeal struct {
+	MealType    MealType
+	Name        string
+	Description string
+	Ingredients []string
+	Allergens   []Allergen
+	// Diet is the most restrictive diet the meal fits
+	Diet Diet
+	Nutrients
+}
+
+// MealPlanDay is the meals planned for one date. Provenance and GeneratedAt
+// change when the day is regenerated.
+type MealPlanDay struct {
+	// Date is midnight UTC of the planned local date
+	Date  time.Time
+	Meals []PlannedMeal
+	// Totals sum the meals
+	Totals      Nutrients
+	GeneratedAt time.Time
+	Provenance
+}
+
+// SumMeals sets the day totals to the sum of its meals
+func (d *MealPlanDay) SumMeals() {
+	d.Totals = Nutrients{}
+	for _, meal := range d.Meals {
+		d.Totals = d.Totals.Add(meal.Nutrients)
+	}
+}
+
+// MealPlan is a user's plan for consecutive days. Targets and Restrictions
+// are what the plan was generated for, and days are regenerated for them too.
+type MealPlan struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	StartDate    time.Time
+	Targets      Nutrients
+	Restrictions []Restriction
+	// Notes are the user's preferences for the plan, such as quick breakfasts
+	Notes     string
+	Days      []MealPlanDay
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MealPlanRequest is what a planner plans the given dates from
+type MealPlanRequest struct {
+	Dates        []time.Time
+	Targets      Nutrients
+	Restrictions []Restriction
+	Notes        string
+	// RecentFoods are foods the user logged often lately, to plan meals
+	// they are likely to eat
+	RecentFoods []string
+	// Avoid are meals planned on other days of the plan, to keep variety
+	Avoid []string
+}
+
+// GeneratedPlan is a planner's result, with the days in request order
+type GeneratedPlan struct {
+	Days []MealPlanDay
+	Provenance
+	Usage TokenUsage
+}
+
+// Validate checks a generated plan against its request. Every requested date
+// must be planned once with breakfast, lunch and dinner, within 20% of the
+// calorie target and without conflicting with the restrictions.
+func (p *GeneratedPlan) Validate(req MealPlanRequest) error {
+	if len(p.Days) != len(req.Dates) {
+		return PlanFailed(fmt.Sprintf("expected %d days, got %d", len(req.Dates), len(p.Days)))
+	}
+
+	for i, day := range p.Days {
+		date := req.Dates[i].Format(time.DateOnly)
+		if !day.Date.Equal(req.Dates[i]) {
+			return PlanFailed(fmt.Sprintf("day %d must be %s, got %s", i+1, date, day.Date.Format(time.DateOnly)))
+		}
+
+		for _, mealType := range []MealType{MealTypeBreakfast, MealTypeLunch, MealTypeDinner} {
+			if !slices.ContainsFunc(day.Meals, func(m PlannedMeal) bool { return m.MealType == mealType }) {
+				return PlanFailed(fmt.Sprintf("%s has no %s", date, mealType))
+			}
+		}
+
+		items := make([]FoodItem, 0, len(day.Meals))
+		for _, meal := range day.Meals {
+			if !meal.MealType.IsValid() {
+				return PlanFailed(fmt.Sprintf("%s has a meal with unknown meal type %q", date, meal.MealType))
+			}
+			if strings.TrimSpace(meal.Name) == "" {
+				return PlanFailed(fmt.Sprintf("%s has a meal without a name", date))
+			}
+			for _, amount := range []struct {
+				label string
+				value float64
+				max   float64
+			}{
+				{"calories", meal.Calories, maxMealCalories},
+				{"protein", meal.Protein, maxMacroGrams},
+				{"fat", meal.Fat, maxMacroGrams},
+				{"carbs", meal.Carbs, maxMacroGrams},
+			} {
+				if reason := checkAmount(meal.Name+" "+amount.label, amount.value, amount.max); reason != "" {
+					return PlanFailed(reason)
+				}
+			}
+			items = append(items, FoodItem{Name: meal.Name, Allergens: meal.Allergens, Diet: meal.Diet})
+		}
+
+		if warnings := CheckRestrictions(items, req.Restrictions); len(warnings) > 0 {
+			messages := make([]string, 0, len(warnings))
+			for _, warning := range warnings {
+				messages = append(messages, warning.Message)
+			}
+			return PlanFailed(fmt.Sprintf("%s conflicts with the restrictions: %s", date, strings.Join(messages, "; ")))
+		}
+
+		if req.Targets.Calories > 0 {
+			deviation := math.Abs(day.Totals.Calories-req.Targets.Calories) / req.Targets.Calories
+			if deviation > mealPlanCalorieTolerance {
+				return PlanFailed(fmt.Sprintf("%s totals %.0f kcal, more than 20%% away from the %.0f kcal target",
+					date, day.Totals.Calories, req.Targets.Calories))
+			}
+		}
+	}
+
+	return nil
+}
+
+// MealPlanner errors carry usage like FoodAnalyzer errors
+type MealPlanner interface {
+	PlanMeals(ctx context.Context, req MealPlanRequest) (*GeneratedPlan, error)
+	// Provenance is the configured model and prompt version new plans are
+	// generated with
+	Provenance() Provenance
+}
+
+type MealPlanRepository interface {
+	Create(ctx context.Context, plan MealPlan) (*MealPlan, error)
+	// Get returns ErrNotFound when the user has no plan with the ID
+	Get(ctx context.Context, userID, id uuid.UUID) (*MealPlan, error)
+	// List returns the user's most recently created plans first
+	List(ctx context.Context, userID uuid.UUID, limit int) ([]MealPlan, error)
+	// UpdateDays replaces the days of a plan
+	UpdateDays(ctx context.Context, plan MealPlan) (*MealPlan, error)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// Product is a packaged food from a barcode catalog. Nutrients and
+// Micronutrients are per 100 g.
+type Product struct {
+	Barcode string
+	Name    string
+	Brand   string
+	// ServingGrams is the label serving size, nil when the label has none
+	ServingGrams *float64
+	Nutrients
+	Micronutrients
+	Source string
+}
+
+// Analysis reports one serving of the product, or 100 g when the serving
+// size is unknown, in the same shape as an image analysis
+func (p Product) Analysis() *DietAnalysis {
+	grams := 100.0
+	if p.ServingGrams != nil {
+		grams = *p.ServingGrams
+	}
+	factor := grams / 100
+
+	name := p.Name
+	if p.Brand != "" {
+		name = p.Brand + " " + p.Name
+	}
+
+	analysis := &DietAnalysis{
+		FoodName: name,
+		Items: []FoodItem{{
+			Name:         name,
+			PortionGrams: grams,
+			Servings:     1,
+			Calories:     p.Calories * factor,
+			Protein:      p.Protein * factor,
+			Fat:          p.Fat * factor,
+			Carbs:        p.Carbs * factor,
+			Micronutrients: Micronutrients{
+				Fiber:        scaleKnown(p.Fiber, factor),
+				Sugar:        scaleKnown(p.Sugar, factor),
+				SaturatedFat: scaleKnown(p.SaturatedFat, factor),
+				SodiumMg:     scaleKnown(p.SodiumMg, factor),
+			},
+		}},
+		IsFood:     true,
+		Confidence: 1,
+	}
+	analysis.SumItems()
+
+	return analysis
+}
+
+func scaleKnown(value *float64, factor float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	scaled := *value * factor
+	return &scaled
+}
+
+// NormalizeBarcode validates an EAN-13, EAN-8, UPC-A or GTIN-14 code and
+// returns the form products are stored under: UPC-A codes gain a leading zero
+// and GTIN-14 codes with a zero indicator digit drop it, giving EAN-13.
+func NormalizeBarcode(code string) (string, bool) {
+	code = strings.TrimSpace(code)
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+
+	switch len(code) {
+	case 8, 13:
+	case 12:
+		code = "0" + code
+	case 14:
+		if code[0] != '0' {
+			return "", false
+		}
+		code = code[1:]
+	default:
+		return "", false
+	}
+
+	if !validCheckDigit(code) {
+		return "", false
+	}
+
+	return code, true
+}
+
+// validCheckDigit verifies the GS1 mod-10 check digit: digits are weighted 3
+// and 1 alternately from the right, excluding the check digit itself
+func validCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+type ProductRepository interface {
+	// Get returns ErrNotFound when no product has the barcode
+	Get(ctx context.Context, barcode string) (*Product, error)
+	Upsert(ctx context.Context, product Product) error
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recipe is a user's dish built from reference foods. Nutrients and
+// Micronutrients are for the whole recipe and are computed from the
+// ingredients when it is saved.
+type Recipe struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	// Servings is how many servings the whole recipe makes
+	Servings    float64
+	Ingredients []RecipeIngredient
+	Nutrients
+	Micronutrients
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RecipeIngredient is an amount of a reference food. Serving and Quantity
+// record the household measure the user entered, such as 2 of "1 cup", and
+// are empty when the amount was given in grams. FoodName, Nutrients and
+// Micronutrients are copied from the food when the recipe is saved.
+type RecipeIngredient struct {
+	FoodID   uuid.UUID
+	FoodName string
+	Grams    float64
+	Serving  string
+	Quantity float64
+	Nutrients
+	Micronutrients
+}
+
+// ComputeNutrition sets the ingredient's nutrients from a food's values per
+// 100 g
+func (i *RecipeIngredient) ComputeNutrition(food Food) {
+	factor := i.Grams / 100
+
+	i.FoodName = food.Name
+	i.Nutrients = food.Nutrients.Scale(factor)
+	i.Micronutrients = food.Micronutrients.Scale(factor)
+}
+
+// SumIngredients sets the recipe totals to the sum of its ingredients. A
+// micronutrient total is only known when every ingredient reports it.
+func (r *Recipe) SumIngredients() {
+	r.Nutrients = Nutrients{}
+	r.Micronutrients = Micronutrients{
+		Fiber:        new(float64),
+		Sugar:        new(float64),
+		SaturatedFat: new(float64),
+		SodiumMg:     new(float64),
+	}
+	for _, ingredient := range r.Ingredients {
+		r.Nutrients = r.Nutrients.Add(ingredient.Nutrients)
+
+		r.Fiber = addKnown(r.Fiber, ingredient.Fiber)
+		r.Sugar = addKnown(r.Sugar, ingredient.Sugar)
+		r.SaturatedFat = addKnown(r.SaturatedFat, ingredient.SaturatedFat)
+		r.SodiumMg = addKnown(r.SodiumMg, ingredient.SodiumMg)
+	}
+}
+
+// PerServing returns the nutrition of the given number of servings
+func (r *Recipe) PerServing(servings float64) (Nutrients, Micronutrients) {
+	factor := servings / r.Servings
+	return r.Nutrients.Scale(factor), r.Micronutrients.Scale(factor)
+}
+
+type RecipeRepository interface {
+	Create(ctx context.Context, recipe Recipe) (*Recipe, error)
+	// Get returns ErrNotFound when the user has no recipe with the ID
+	Get(ctx context.Context, userID, id uuid.UUID) (*Recipe, error)
+	// List returns the user's recipes by name
+	List(ctx context.Context, userID uuid.UUID) ([]Recipe, error)
+	Update(ctx context.Context, recipe Recipe) (*Recipe, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Allergen is a major food allergen the analyzer flags in a food item
+type Allergen string
+
+const (
+	AllergenGluten    Allergen = "gluten"
+	AllergenMilk      Allergen = "milk"
+	AllergenEggs      Allergen = "eggs"
+	AllergenFish      Allergen = "fish"
+	AllergenShellfish Allergen = "shellfish"
+	AllergenPeanuts   Allergen = "peanuts"
+	AllergenTreeNuts  Allergen = "tree_nuts"
+	AllergenSoy       Allergen = "soy"
+	AllergenSesame    Allergen = "sesame"
+)
+
+// Allergens lists every allergen the analyzer reports
+var Allergens = []Allergen{
+	AllergenGluten, AllergenMilk, AllergenEggs, AllergenFish, AllergenShellfish,
+	AllergenPeanuts, AllergenTreeNuts, AllergenSoy, AllergenSesame,
+}
+
+func (a Allergen) IsValid() bool {
+	return slices.Contains(Allergens, a)
+}
+
+// Diet is the most restrictive diet a food item fits. Each diet also fits
+// the ones after it: a vegan item is vegetarian and pescatarian too.
+type Diet string
+
+const (
+	DietVegan       Diet = "vegan"
+	DietVegetarian  Diet = "vegetarian"
+	DietPescatarian Diet = "pescatarian"
+	DietOmnivore    Diet = "omnivore"
+)
+
+var diets = []Diet{DietVegan, DietVegetarian, DietPescatarian, DietOmnivore}
+
+func (d Diet) IsValid() bool {
+	return slices.Contains(diets, d)
+}
+
+// Fits reports whether an item of diet d may be eaten on diet other
+func (d Diet) Fits(other Diet) bool {
+	return slices.Index(diets, d) <= slices.Index(diets, other)
+}
+
+// Restriction is a diet or intolerance a user declared
+type Restriction string
+
+const (
+	RestrictionVegetarian        Restriction = "vegetarian"
+	RestrictionVegan             Restriction = "vegan"
+	RestrictionPescatarian       Restriction = "pescatarian"
+	RestrictionGlutenFree        Restriction = "gluten_free"
+	RestrictionLactoseIntolerant Restriction = "lactose_intolerant"
+	RestrictionDairyFree         Restriction = "dairy_free"
+	RestrictionNutAllergy        Restriction = "nut_allergy"
+	RestrictionPeanutAllergy     Restriction = "peanut_allergy"
+	RestrictionTreeNutAllergy    Restriction = "tree_nut_allergy"
+	RestrictionEggAllergy        Restriction = "egg_allergy"
+	RestrictionFishAllergy       Restriction = "fish_allergy"
+	RestrictionShellfishAllergy  Restriction = "shellfish_allergy"
+	RestrictionSoyAllergy        Restriction = "soy_allergy"
+	RestrictionSesameAllergy     Restriction = "sesame_allergy"
+)
+
+// restrictionRules maps each restriction to the diet it requires, if any,
+// and the allergens it excludes
+var restrictionRules = map[Restriction]struct {
+	diet      Diet
+	allergens []Allergen
+}{
+	RestrictionVegetarian:        {diet: DietVegetarian},
+	RestrictionVegan:             {diet: DietVegan},
+	RestrictionPescatarian:       {diet: DietPescatarian},
+	RestrictionGlutenFree:        {allergens: []Allergen{AllergenGluten}},
+	RestrictionLactoseIntolerant: {allergens: []Allergen{AllergenMilk}},
+	RestrictionDairyFree:         {allergens: []Allergen{AllergenMilk}},
+	RestrictionNutAllergy:        {allergens: []Allergen{AllergenPeanuts, AllergenTreeNuts}},
+	RestrictionPeanutAllergy:     {allergens: []Allergen{AllergenPeanuts}},
+	RestrictionTreeNutAllergy:    {allergens: []Allergen{AllergenTreeNuts}},
+	RestrictionEggAllergy:        {allergens: []Allergen{AllergenEggs}},
+	RestrictionFishAllergy:       {allergens: []Allergen{AllergenFish}},
+	RestrictionShellfishAllergy:  {allergens: []Allergen{AllergenShellfish}},
+	RestrictionSoyAllergy:        {allergens: []Allergen{AllergenSoy}},
+	RestrictionSesameAllergy:     {allergens: []Allergen{AllergenSesame}},
+}
+
+func (r Restriction) IsValid() bool {
+	_, ok := restrictionRules[r]
+	return ok
+}
+
+// RestrictionWarning flags a food item that conflicts with one of the user's
+// restrictions. Allergen is empty for diet conflicts.
+type RestrictionWarning struct {
+	Restriction Restriction
+	Item        string
+	Allergen    Allergen
+	Message     string
+}
+
+// CheckRestrictions returns a warning for every item and restriction that
+// conflict, in item order. Items with an unknown diet are not checked
+// against diets.
+func CheckRestrictions(items []FoodItem, restrictions []Restriction) []RestrictionWarning {
+	var warnings []RestrictionWarning
+	for _, item := range items {
+		for _, restriction := range restrictions {
+			rule := restrictionRules[restriction]
+
+			if rule.diet != "" && item.Diet != "" && !item.Diet.Fits(rule.diet) {
+				warnings = append(warnings, RestrictionWarning{
+					Restriction: restriction,
+					Item:        item.Name,
+					Message:     fmt.Sprintf("%s is not %s", item.Name, rule.diet),
+				})
+			}
+
+			for _, allergen := range rule.allergens {
+				if slices.Contains(item.Allergens, allergen) {
+					warnings = append(warnings, RestrictionWarning{
+						Restriction: restriction,
+						Item:        item.Name,
+						Allergen:    allergen,
+						Message:     fmt.Sprintf("%s may contain %s", item.Name, allergenLabels[allergen]),
+					})
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+var allergenLabels = map[Allergen]string{
+	AllergenGluten:    "gluten",
+	AllergenMilk:      "milk",
+	AllergenEggs:      "eggs",
+	AllergenFish:      "fish",
+	AllergenShellfish: "shellfish",
+	AllergenPeanuts:   "peanuts",
+	AllergenTreeNuts:  "tree nuts",
+	AllergenSoy:       "soy",
+	AllergenSesame:    "sesame",
+}
+
+// UserRestrictions are the diets and intolerances a user declared
+type UserRestrictions struct {
+	UserID       uuid.UUID
+	Restrictions []Restriction
+	UpdatedAt    time.Time
+}
+
+type RestrictionRepository interface {
+	// Get returns ErrNotFound when the user has not declared restrictions
+	Get(ctx context.Context, userID uuid.UUID) (*UserRestrictions, error)
+	Upsert(ctx context.Context, restrictions UserRestrictions) (*UserRestrictions, error)
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Sex string
+
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+)
+
+func (s Sex) IsValid() bool {
+	return s == SexMale || s == SexFemale
+}
+
+type ActivityLevel string
+
+const (
+	ActivitySedentary  ActivityLevel = "sedentary"
+	ActivityLight      ActivityLevel = "light"
+	ActivityModerate   ActivityLevel = "moderate"
+	ActivityActive     ActivityLevel = "active"
+	ActivityVeryActive ActivityLevel = "very_active"
+)
+
+// activityMultipliers scale basal metabolic rate to total daily energy
+// expenditure
+var activityMultipliers = map[ActivityLevel]float64{
+	ActivitySedentary:  1.2,
+	ActivityLight:      1.375,
+	ActivityModerate:   1.55,
+	ActivityActive:     1.725,
+	ActivityVeryActive: 1.9,
+}
+
+func (a ActivityLevel) IsValid() bool {
+	_, ok := activityMultipliers[a]
+	return ok
+}
+
+type TargetSource string
+
+const (
+	TargetSourceManual     TargetSource = "manual"
+	TargetSourceCalculated TargetSource = "calculated"
+)
+
+// BodyProfile holds the measurements used to calculate nutrition targets
+type BodyProfile struct {
+	HeightCm      float64
+	WeightKg      float64
+	Age           int
+	Sex           Sex
+	ActivityLevel ActivityLevel
+}
+
+// CalculateTargets estimates daily targets from the profile. Calories use the
+// Mifflin-St Jeor equation scaled by activity level; protein is 1.6 g per kg
+// of body weight, fat is 30% of calories and carbs fill the remainder.
+func (p BodyProfile) CalculateTargets() Nutrients {
+	bmr := 10*p.WeightKg + 6.25*p.HeightCm - 5*float64(p.Age)
+	if p.Sex == SexMale {
+		bmr += 5
+	} else {
+		bmr -= 161
+	}
+
+	calories := math.Round(bmr * activityMultipliers[p.ActivityLevel])
+	protein := math.Round(1.6 * p.WeightKg)
+	fat := math.Round(calories * 0.3 / 9)
+	carbs := math.Round(max(0, calories-protein*4-fat*9) / 4)
+
+	return Nutrients{
+		Calories: calories,
+		Protein:  protein,
+		Fat:      fat,
+		Carbs:    carbs,
+	}
+}
+
+// UserSettings are a user's daily nutrition targets. Profile is kept when the
+// user provided one, even if the targets were then set manually.
+type UserSettings struct {
+	UserID       uuid.UUID
+	Targets      Nutrients
+	TargetSource TargetSource
+	Profile      *BodyProfile
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Progress compares consumed nutrients against a target. Remaining is
+// negative once the target is exceeded and Percent is zero for a zero target.
+type Progress struct {
+	Target    Nutrients
+	Remaining Nutrients
+	Percent   Nutrients
+}
+
+func NewProgress(target, consumed Nutrients) Progress {
+	return Progress{
+		Target: target,
+		Remaining: Nutrients{
+			Calories: target.Calories - consumed.Calories,
+			Protein:  target.Protein - consumed.Protein,
+			Fat:      target.Fat - consumed.Fat,
+			Carbs:    target.Carbs - consumed.Carbs,
+		},
+		Percent: Nutrients{
+			Calories: percentOf(consumed.Calories, target.Calories),
+			Protein:  percentOf(consumed.Protein, target.Protein),
+			Fat:      percentOf(consumed.Fat, target.Fat),
+			Carbs:    percentOf(consumed.Carbs, target.Carbs),
+		},
+	}
+}
+
+func percentOf(value, target float64) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return math.Round(value/target*1000) / 10
+}
+
+type SettingsRepository interface {
+	// Get returns ErrNotFound when the user has not saved any settings
+	Get(ctx context.Context, userID uuid.UUID) (*UserSettings, error)
+	Upsert(ctx context.Context, settings UserSettings) (*UserSettings, error)
+}
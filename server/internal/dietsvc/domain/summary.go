@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Granularity string
+
+const (
+	GranularityDay  Granularity = "day"
+	GranularityWeek Granularity = "week"
+)
+
+func (g Granularity) IsValid() bool {
+	return g == GranularityDay || g == GranularityWeek
+}
+
+type Nutrients struct {
+	Calories float64
+	Protein  float64
+	Fat      float64
+	Carbs    float64
+}
+
+func (n Nutrients) Add(other Nutrients) Nutrients {
+	return Nutrients{
+		Calories: n.Calories + other.Calories,
+		Protein:  n.Protein + other.Protein,
+		Fat:      n.Fat + other.Fat,
+		Carbs:    n.Carbs + other.Carbs,
+	}
+}
+
+// Scale returns n with every nutrient multiplied by factor
+func (n Nutrients) Scale(factor float64) Nutrients {
+	return Nutrients{
+		Calories: n.Calories * factor,
+		Protein:  n.Protein * factor,
+		Fat:      n.Fat * factor,
+		Carbs:    n.Carbs * factor,
+	}
+}
+
+// PeriodSummary aggregates a user's entries over one day or week in their
+// local time zone. DailyAverages are averaged over days with at least one
+// entry.
+type PeriodSummary struct {
+	Start         time.Time
+	EntryCount    int
+	DaysLogged    int
+	Totals        Nutrients
+	DailyAverages Nutrients
+	// Progress compares Totals with the user's targets for the days of the
+	// period inside the summary range; nil when the user has no targets
+	Progress *Progress
+}
+
+type SummaryQuery struct {
+	UserID      uuid.UUID
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+	TimeZone    string
+}
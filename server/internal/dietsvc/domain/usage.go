@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageKind is what a usage ledger record was charged for
+type UsageKind string
+
+const (
+	UsageKindImage    UsageKind = "image"
+	UsageKindText     UsageKind = "text"
+	UsageKindMealPlan UsageKind = "meal_plan"
+)
+
+// TokenUsage is what one analyzer call consumed, summed over any repair
+// and provider retries. CostUSD is an estimate from the model's list prices.
+type TokenUsage struct {
+	Model            string
+	PromptTokens     int64
+	CompletionTokens int64
+	CostUSD          float64
+}
+
+// Add sums other into u, keeping the model of the latest call
+func (u *TokenUsage) Add(other TokenUsage) {
+	if other.Model != "" {
+		u.Model = other.Model
+	}
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.CostUSD += other.CostUSD
+}
+
+func (u TokenUsage) IsZero() bool {
+	return u.PromptTokens == 0 && u.CompletionTokens == 0 && u.CostUSD == 0
+}
+
+// usageError is a failure after provider calls that were already paid for
+type usageError struct {
+	err   error
+	usage TokenUsage
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// WithUsage attaches the usage of failed provider calls to err so it can
+// still be recorded. usage must include any usage already attached to err.
+// It returns err unchanged when nothing was consumed.
+func WithUsage(err error, usage TokenUsage) error {
+	if err == nil || usage.IsZero() {
+		return err
+	}
+
+	return &usageError{err: err, usage: usage}
+}
+
+// UsageOf returns the usage attached to err by the outermost WithUsage
+func UsageOf(err error) TokenUsage {
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return usageErr.usage
+	}
+
+	return TokenUsage{}
+}
+
+// UsageRecord is one analysis in a user's usage ledger. Cache hits are
+// recorded with zero tokens and cost; failed analyses are recorded when the
+// provider still charged for them.
+type UsageRecord struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Kind   UsageKind
+	Cached bool
+	TokenUsage
+	CreatedAt time.Time
+}
+
+// UsageTotals aggregates a user's ledger over a period. Analyses counts
+// only uncached analyses, which are the ones that count toward quotas.
+type UsageTotals struct {
+	Analyses int
+	CostUSD  float64
+}
+
+type UsageRepository interface {
+	Record(ctx context.Context, record UsageRecord) error
+	// TotalsSince sums the user's usage recorded at or after since
+	TotalsSince(ctx context.Context, userID uuid.UUID, since time.Time) (UsageTotals, error)
+}
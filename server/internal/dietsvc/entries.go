@@ -0,0 +1,277 @@
+package dietsvc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	defaultPageSize    = 50
+	maxPageSize        = 200
+	maxEntryNameLength = 200
+	maxEntryCalories   = 10000
+	maxEntryMacroGrams = 1000
+	maxEntrySodiumMg   = 50000
+	maxLoggedServings  = 20
+)
+
+type ListEntriesOptions struct {
+	From   *time.Time
+	To     *time.Time
+	Cursor string
+	Limit  int
+}
+
+type EntryPage struct {
+	Entries    []domain.DietEntry
+	NextCursor string
+}
+
+// LogOptions describe a new entry for some servings of saved values, such
+// as a recipe or an earlier entry. Servings defaults to 1 and MealType is
+// inferred from EatenAt when empty.
+type LogOptions struct {
+	Servings float64
+	EatenAt  time.Time
+	MealType domain.MealType
+}
+
+func (opts *LogOptions) normalize() error {
+	if opts.Servings == 0 {
+		opts.Servings = 1
+	}
+	if !isValidAmount(opts.Servings, maxLoggedServings) || opts.Servings <= 0 {
+		return domain.InvalidEntry("servings", "must be greater than 0 and at most 20")
+	}
+
+	return nil
+}
+
+func (s *Service) CreateEntry(ctx context.Context, userID uuid.UUID, entry domain.DietEntry) (*domain.DietEntry, error) {
+	entry.UserID = userID
+	if err := normalizeEntry(&entry); err != nil {
+		return nil, err
+	}
+	if err := s.resolveProvenance(ctx, &entry); err != nil {
+		return nil, err
+	}
+
+	created, err := s.entryRepo.Create(ctx, entry)
+	if err != nil {
+		return nil, domain.WrapError("failed to create diet entry", err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetEntry(ctx context.Context, userID, id uuid.UUID) (*domain.DietEntry, error) {
+	entry, err := s.entryRepo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, domain.WrapError("failed to get diet entry", err)
+	}
+
+	return entry, nil
+}
+
+// ListEntries returns the user's entries newest first. NextCursor is empty on
+// the last page.
+func (s *Service) ListEntries(ctx context.Context, userID uuid.UUID, opts ListEntriesOptions) (*EntryPage, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	filter := domain.EntryFilter{
+		From: opts.From,
+		To:   opts.To,
+		// Fetch one extra row to know whether another page exists
+		Limit: limit + 1,
+	}
+
+	if opts.Cursor != "" {
+		cursor, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter.Cursor = cursor
+	}
+
+	entries, err := s.entryRepo.List(ctx, userID, filter)
+	if err != nil {
+		return nil, domain.WrapError("failed to list diet entries", err)
+	}
+
+	page := &EntryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		page.NextCursor = encodeCursor(domain.EntryCursor{EatenAt: last.EatenAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+func (s *Service) UpdateEntry(ctx context.Context, userID uuid.UUID, entry domain.DietEntry) (*domain.DietEntry, error) {
+	entry.UserID = userID
+	if err := normalizeEntry(&entry); err != nil {
+		return nil, err
+	}
+	if err := s.resolveProvenance(ctx, &entry); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.entryRepo.Update(ctx, entry)
+	if err != nil {
+		return nil, domain.WrapError("failed to update diet entry", err)
+	}
+
+	return updated, nil
+}
+
+func (s *Service) DeleteEntry(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.entryRepo.Delete(ctx, userID, id); err != nil {
+		return domain.WrapError("failed to delete diet entry", err)
+	}
+
+	return nil
+}
+
+// resolveProvenance copies the model and prompt version of the analysis the
+// entry links to, which must be one of the user's analyses
+func (s *Service) resolveProvenance(ctx context.Context, entry *domain.DietEntry) error {
+	entry.Provenance = domain.Provenance{}
+	if entry.AnalysisID == nil {
+		return nil
+	}
+
+	analysis, err := s.analysisRepo.Get(ctx, entry.UserID, *entry.AnalysisID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.InvalidEntry("analysis_id", "does not match an analysis of the user")
+		}
+		return domain.WrapError("failed to get analysis", err)
+	}
+	entry.Provenance = domain.Provenance{
+		Model:         analysis.Model,
+		PromptVersion: analysis.PromptVersion,
+	}
+
+	return nil
+}
+
+func normalizeEntry(entry *domain.DietEntry) error {
+	entry.Name = strings.TrimSpace(entry.Name)
+	entry.Description = strings.TrimSpace(entry.Description)
+
+	if entry.Name == "" {
+		return domain.InvalidEntry("name", "is required")
+	}
+	if utf8.RuneCountInString(entry.Name) > maxEntryNameLength {
+		return domain.InvalidEntry("name", "must not exceed 200 characters")
+	}
+	if utf8.RuneCountInString(entry.Description) > maxDescriptionLength {
+		return domain.InvalidEntry("description", "must not exceed 1000 characters")
+	}
+
+	if entry.EatenAt.IsZero() {
+		return domain.InvalidEntry("eaten_at", "is required")
+	}
+
+	if !isValidAmount(entry.Calories, maxEntryCalories) {
+		return domain.InvalidEntry("calories", "must be between 0 and 10000")
+	}
+	for _, macro := range []struct {
+		field string
+		value float64
+	}{
+		{"protein", entry.Protein},
+		{"fat", entry.Fat},
+		{"carbs", entry.Carbs},
+	} {
+		if !isValidAmount(macro.value, maxEntryMacroGrams) {
+			return domain.InvalidEntry(macro.field, "must be between 0 and 1000")
+		}
+	}
+
+	for _, micro := range []struct {
+		field string
+		value *float64
+		max   float64
+	}{
+		{"fiber", entry.Fiber, maxEntryMacroGrams},
+		{"sugar", entry.Sugar, maxEntryMacroGrams},
+		{"saturated_fat", entry.SaturatedFat, maxEntryMacroGrams},
+		{"sodium_mg", entry.SodiumMg, maxEntrySodiumMg},
+	} {
+		if micro.value != nil && !isValidAmount(*micro.value, micro.max) {
+			return domain.InvalidEntry(micro.field, fmt.Sprintf("must be between 0 and %.0f", micro.max))
+		}
+	}
+
+	if entry.Source == "" {
+		entry.Source = domain.EntrySourceManual
+	}
+	if !entry.Source.IsValid() {
+		return domain.InvalidEntry("source", "must be one of manual, image, text or recipe")
+	}
+
+	// EatenAt keeps the offset the client sent, which places it on the
+	// user's clock
+	if entry.MealType == "" {
+		entry.MealType = domain.MealTypeAt(entry.EatenAt)
+	}
+	if !entry.MealType.IsValid() {
+		return domain.InvalidEntry("meal_type", "must be one of breakfast, lunch, dinner or snack")
+	}
+
+	if entry.Confidence != nil && !isValidAmount(*entry.Confidence, 1) {
+		return domain.InvalidEntry("confidence", "must be between 0 and 1")
+	}
+
+	return nil
+}
+
+func isValidAmount(value, max float64) bool {
+	return !math.IsNaN(value) && value >= 0 && value <= max
+}
+
+// encodeCursor serializes a page boundary as an opaque URL-safe token
+func encodeCursor(c domain.EntryCursor) string {
+	raw := fmt.Sprintf("%d:%s", c.EatenAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(token string) (*domain.EntryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	entryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EntryCursor{EatenAt: time.Unix(0, n), ID: entryID}, nil
+}
@@ -0,0 +1,152 @@
+package dietsvc
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	recentsLookback    = 90 * 24 * time.Hour
+	defaultRecentLimit = 20
+	maxRecentLimit     = 50
+)
+
+type RecentsOptions struct {
+	Order domain.RecentsOrder
+	Limit int
+}
+
+// Recents returns the foods the user logged in the last 90 days, one per
+// name, most recent first or most often logged first
+func (s *Service) Recents(ctx context.Context, userID uuid.UUID, opts RecentsOptions) ([]domain.RecentFood, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultRecentLimit
+	}
+	limit = min(limit, maxRecentLimit)
+
+	recents, err := s.entryRepo.ListRecent(ctx, userID, time.Now().Add(-recentsLookback))
+	if err != nil {
+		return nil, domain.WrapError("failed to list recent foods", err)
+	}
+
+	byLastEaten := func(a, b domain.RecentFood) int {
+		return b.Entry.EatenAt.Compare(a.Entry.EatenAt)
+	}
+	if opts.Order == domain.RecentsOrderFrequent {
+		slices.SortFunc(recents, func(a, b domain.RecentFood) int {
+			return cmp.Or(cmp.Compare(b.LogCount, a.LogCount), byLastEaten(a, b))
+		})
+	} else {
+		slices.SortFunc(recents, byLastEaten)
+	}
+
+	return recents[:min(limit, len(recents))], nil
+}
+
+// RelogEntry creates a new entry with the values of an earlier one, scaled to
+// the given servings, without analyzing anything again
+func (s *Service) RelogEntry(ctx context.Context, userID, id uuid.UUID, opts LogOptions) (*domain.DietEntry, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.GetEntry(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.logCopy(ctx, userID, savedValues(entry), opts)
+}
+
+func (s *Service) ListFavorites(ctx context.Context, userID uuid.UUID) ([]domain.Favorite, error) {
+	favorites, err := s.favoriteRepo.List(ctx, userID)
+	if err != nil {
+		return nil, domain.WrapError("failed to list favorites", err)
+	}
+
+	return favorites, nil
+}
+
+// AddFavorite saves the values of an entry as a favorite. Favoriting another
+// entry with the same name replaces the saved values.
+func (s *Service) AddFavorite(ctx context.Context, userID, entryID uuid.UUID) (*domain.Favorite, error) {
+	entry, err := s.GetEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := savedValues(entry)
+	saved.UserID = userID
+
+	favorite, err := s.favoriteRepo.Upsert(ctx, saved)
+	if err != nil {
+		return nil, domain.WrapError("failed to save favorite", err)
+	}
+
+	return favorite, nil
+}
+
+func (s *Service) DeleteFavorite(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.favoriteRepo.Delete(ctx, userID, id); err != nil {
+		return domain.WrapError("failed to delete favorite", err)
+	}
+
+	return nil
+}
+
+// LogFavorite creates an entry with the saved values of a favorite
+func (s *Service) LogFavorite(ctx context.Context, userID, id uuid.UUID, opts LogOptions) (*domain.DietEntry, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	favorite, err := s.favoriteRepo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, domain.WrapError("failed to get favorite", err)
+	}
+
+	return s.logCopy(ctx, userID, *favorite, opts)
+}
+
+// logCopy creates an entry from saved values scaled to opts.Servings. The
+// copy keeps the source and confidence of the values but not the photo.
+func (s *Service) logCopy(ctx context.Context, userID uuid.UUID, saved domain.Favorite, opts LogOptions) (*domain.DietEntry, error) {
+	nutrients := saved.Nutrients.Scale(opts.Servings)
+
+	return s.CreateEntry(ctx, userID, domain.DietEntry{
+		Name:           saved.Name,
+		Description:    saved.Description,
+		Calories:       nutrients.Calories,
+		Protein:        nutrients.Protein,
+		Fat:            nutrients.Fat,
+		Carbs:          nutrients.Carbs,
+		EatenAt:        opts.EatenAt,
+		Source:         saved.Source,
+		MealType:       opts.MealType,
+		Confidence:     saved.Confidence,
+		Micronutrients: saved.Micronutrients.Scale(opts.Servings),
+	})
+}
+
+// savedValues copies what is kept of an entry for relogging
+func savedValues(entry *domain.DietEntry) domain.Favorite {
+	return domain.Favorite{
+		Name:        entry.Name,
+		Description: entry.Description,
+		Nutrients: domain.Nutrients{
+			Calories: entry.Calories,
+			Protein:  entry.Protein,
+			Fat:      entry.Fat,
+			Carbs:    entry.Carbs,
+		},
+		Micronutrients: entry.Micronutrients,
+		Source:         entry.Source,
+		Confidence:     entry.Confidence,
+	}
+}
@@ -0,0 +1,96 @@
+package dietsvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	defaultFoodResults = 20
+	maxFoodResults     = 50
+	minFoodQueryLength = 2
+	maxFoodQueryLength = 100
+
+	// Per 100 g, nothing exceeds pure fat's energy or 100 g of a nutrient
+	maxFoodCalories = 900
+	maxFoodGrams    = 100
+	maxFoodSodiumMg = 40000
+	maxServingGrams = 5000
+)
+
+// SearchFoods returns reference foods whose names fuzzily match query
+func (s *Service) SearchFoods(ctx context.Context, query string, limit int) ([]domain.Food, error) {
+	query = strings.TrimSpace(query)
+	if n := utf8.RuneCountInString(query); n < minFoodQueryLength || n > maxFoodQueryLength {
+		return nil, domain.ErrInvalidFoodQuery
+	}
+
+	if limit <= 0 {
+		limit = defaultFoodResults
+	}
+	limit = min(limit, maxFoodResults)
+
+	foods, err := s.foodRepo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, domain.WrapError("failed to search foods", err)
+	}
+
+	return foods, nil
+}
+
+// ImportFoods validates and upserts reference foods, returning how many were
+// written. It stops at the first invalid food.
+func (s *Service) ImportFoods(ctx context.Context, foods []domain.Food) (int, error) {
+	for i, food := range foods {
+		if err := validateFood(food); err != nil {
+			return i, fmt.Errorf("food %q: %w", food.SourceID, err)
+		}
+		if err := s.foodRepo.Upsert(ctx, food); err != nil {
+			return i, fmt.Errorf("failed to import food %q: %w", food.SourceID, err)
+		}
+	}
+
+	return len(foods), nil
+}
+
+func validateFood(food domain.Food) error {
+	if food.Source == "" || food.SourceID == "" {
+		return fmt.Errorf("source and source ID are required")
+	}
+	if strings.TrimSpace(food.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if !isValidAmount(food.Calories, maxFoodCalories) {
+		return fmt.Errorf("calories must be between 0 and %d per 100 g", maxFoodCalories)
+	}
+	for _, amount := range []struct {
+		name  string
+		value *float64
+		max   float64
+	}{
+		{"protein", &food.Protein, maxFoodGrams},
+		{"fat", &food.Fat, maxFoodGrams},
+		{"carbs", &food.Carbs, maxFoodGrams},
+		{"fiber", food.Fiber, maxFoodGrams},
+		{"sugar", food.Sugar, maxFoodGrams},
+		{"saturated_fat", food.SaturatedFat, maxFoodGrams},
+		{"sodium_mg", food.SodiumMg, maxFoodSodiumMg},
+	} {
+		if amount.value != nil && !isValidAmount(*amount.value, amount.max) {
+			return fmt.Errorf("%s must be between 0 and %.0f per 100 g", amount.name, amount.max)
+		}
+	}
+
+	for _, serving := range food.Servings {
+		if serving.Label == "" || serving.Grams <= 0 || serving.Grams > maxServingGrams {
+			return fmt.Errorf("serving %q must have a label and between 0 and %d grams", serving.Label, maxServingGrams)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package dietsvc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+	"github.com/priyanshujain/balancewise/server/internal/generic/httperrors"
+)
+
+const (
+	maxCallbackURLLength = 2048
+
+	// defaultJobTimeout bounds a single run of a job when ServiceConfig sets
+	// no JobTimeout. A running job whose worker died is reclaimed once
+	// staleJobMargin has passed beyond the job timeout.
+	defaultJobTimeout = 2 * time.Minute
+	staleJobMargin    = time.Minute
+	maxJobAttempts    = 3
+
+	jobPollInterval = 2 * time.Second
+	// jobRetryDelay, multiplied by the attempt number, is how long a job that
+	// hit a provider error waits in the queue before it is claimed again
+	jobRetryDelay = 15 * time.Second
+
+	callbackAttempts = 3
+	callbackBackoff  = 2 * time.Second
+)
+
+type SubmitJobOptions struct {
+	UserID uuid.UUID
+	Kind   domain.JobKind
+	// ImageData and BypassCache apply to image jobs
+	ImageData   []byte
+	BypassCache bool
+	// Hints apply to image jobs; text jobs only use the meal type and local
+	// time
+	Hints domain.AnalysisHints
+	// Description applies to text jobs
+	Description string
+	// CallbackURL optionally receives a POST once the job completes
+	CallbackURL string
+}
+
+// SubmitJob validates the request and queues it for a worker. Invalid images,
+// hints and descriptions fail here, as they would synchronously, and so do
+// exhausted quotas except for image jobs that may hit the cache.
+func (s *Service) SubmitJob(ctx context.Context, opts SubmitJobOptions) (*domain.AnalysisJob, error) {
+	if err := validateCallbackURL(opts.CallbackURL); err != nil {
+		return nil, err
+	}
+
+	job := domain.AnalysisJob{
+		UserID:      opts.UserID,
+		Kind:        opts.Kind,
+		BypassCache: opts.BypassCache,
+		CallbackURL: opts.CallbackURL,
+	}
+
+	var err error
+	switch opts.Kind {
+	case domain.JobKindImage:
+		job.Image, job.Hints, err = s.prepareImage(opts.ImageData, opts.Hints)
+	case domain.JobKindText:
+		if job.Description, err = normalizeDescription(opts.Description); err == nil {
+			job.Hints, err = normalizeHints(opts.Hints)
+		}
+	default:
+		err = domain.WrapError("failed to submit job", errors.New("unknown job kind "+string(opts.Kind)))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Image jobs may be served from the cache for free, so unless they bypass
+	// it their quota is checked when they run
+	if opts.Kind != domain.JobKindImage || opts.BypassCache {
+		if err := s.checkQuota(ctx, opts.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.jobRepo.Create(ctx, job)
+	if err != nil {
+		return nil, domain.WrapError("failed to create analysis job", err)
+	}
+
+	// Wake an idle worker instead of waiting for the next poll
+	select {
+	case s.jobWake <- struct{}{}:
+	default:
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetJob(ctx context.Context, userID, id uuid.UUID) (*domain.AnalysisJob, error) {
+	job, err := s.jobRepo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, domain.WrapError("failed to get analysis job", err)
+	}
+
+	return job, nil
+}
+
+// RunJobWorkers processes queued jobs with the given number of workers until
+// ctx is cancelled. Jobs interrupted by shutdown return to the queue.
+func (s *Service) RunJobWorkers(ctx context.Context, workers int) error {
+	g, gCtx := errgroup.WithContext(ctx)
+	for range workers {
+		g.Go(func() error {
+			s.runJobWorker(gCtx)
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func (s *Service) runJobWorker(ctx context.Context) {
+	for {
+		job, err := s.jobRepo.ClaimNext(ctx, time.Now().Add(-s.jobTimeout-staleJobMargin))
+		if err == nil {
+			s.runJob(ctx, job)
+			continue
+		}
+		if !errors.Is(err, domain.ErrNotFound) && ctx.Err() == nil {
+			slog.Error("failed to claim analysis job", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.jobWake:
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+func (s *Service) runJob(ctx context.Context, job *domain.AnalysisJob) {
+	if job.Attempts > maxJobAttempts {
+		job.Status = domain.JobStatusFailed
+		job.Error = &domain.JobError{Code: "JOB_ABANDONED", Message: "analysis did not finish after repeated attempts"}
+		s.completeJob(ctx, job)
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, s.jobTimeout)
+	defer cancel()
+
+	var err error
+	switch job.Kind {
+	case domain.JobKindImage:
+		var result *AnalyzeFoodResult
+		if result, err = s.analyzeImage(runCtx, job.UserID, job.Image, job.Hints, job.BypassCache); err == nil {
+			job.Result, job.Cached = result.Analysis, result.Cached
+		}
+	case domain.JobKindText:
+		job.Result, err = s.analyzeText(runCtx, job.UserID, job.Description, job.Hints)
+	default:
+		err = domain.WrapError("failed to run job", errors.New("unknown job kind "+string(job.Kind)))
+	}
+
+	if ctx.Err() != nil {
+		// Shutting down: let another worker pick the job up
+		s.releaseJob(job, time.Now())
+		return
+	}
+
+	if err != nil {
+		httpErr := httperrors.From(err)
+		if httpErr.HttpStatus >= 500 && job.Attempts < maxJobAttempts {
+			// Requeue with a delay so the worker is free for other jobs
+			// while the provider recovers
+			slog.Warn("analysis job failed, retrying", "job_id", job.ID, "attempt", job.Attempts, "error", err)
+			s.releaseJob(job, time.Now().Add(time.Duration(job.Attempts)*jobRetryDelay))
+			return
+		}
+
+		slog.Error("analysis job failed", "job_id", job.ID, "error", err)
+		job.Status = domain.JobStatusFailed
+		job.Error = &domain.JobError{Code: httpErr.Code, Message: httpErr.Message}
+	} else {
+		job.Status = domain.JobStatusSucceeded
+	}
+
+	s.completeJob(ctx, job)
+}
+
+// releaseJob returns a job to the queue from availableAt. It uses its own
+// context so jobs are still requeued while the workers are shutting down.
+func (s *Service) releaseJob(job *domain.AnalysisJob, availableAt time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.jobRepo.Release(ctx, *job, availableAt); err != nil {
+		slog.Error("failed to release analysis job", "job_id", job.ID, "error", err)
+	}
+}
+
+// completeJob stores the outcome and sends the callback. A job reclaimed by
+// another worker meanwhile is left to that worker, so it completes once.
+func (s *Service) completeJob(ctx context.Context, job *domain.AnalysisJob) {
+	completed, err := s.jobRepo.Complete(ctx, *job)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			slog.Warn("analysis job was reclaimed by another worker", "job_id", job.ID, "attempt", job.Attempts)
+			return
+		}
+		slog.Error("failed to complete analysis job", "job_id", job.ID, "error", err)
+		return
+	}
+
+	slog.Info("analysis job completed", "job_id", completed.ID, "status", completed.Status)
+
+	if completed.CallbackURL != "" && s.notifier != nil {
+		s.notifyJob(ctx, completed)
+	}
+}
+
+// notifyJob delivers the completion callback, retrying with backoff. Clients
+// that miss every attempt can still poll the job.
+func (s *Service) notifyJob(ctx context.Context, job *domain.AnalysisJob) {
+	event := domain.JobEvent{
+		JobID:       job.ID,
+		Status:      job.Status,
+		CompletedAt: *job.CompletedAt,
+	}
+
+	backoff := callbackBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.notifier.Notify(ctx, job.CallbackURL, event)
+		if err == nil {
+			return
+		}
+		if attempt == callbackAttempts {
+			slog.Error("failed to deliver job callback", "job_id", job.ID, "error", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func validateCallbackURL(callbackURL string) error {
+	if callbackURL == "" {
+		return nil
+	}
+	if len(callbackURL) > maxCallbackURLLength {
+		return domain.ErrInvalidCallbackURL
+	}
+
+	u, err := url.Parse(callbackURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return domain.ErrInvalidCallbackURL
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package dietsvc
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	maxMealPlanNotesLength = 500
+	defaultMealPlanLimit   = 20
+	// mealPlanRecentFoods is how many of the most often logged foods are
+	// given to the planner as the user's history
+	mealPlanRecentFoods = 20
+)
+
+type MealPlanOptions struct {
+	// StartDate is the first planned date; defaults to today in UTC
+	StartDate time.Time
+	Notes     string
+}
+
+// CreateMealPlan generates and stores a plan for the week starting at
+// opts.StartDate from the user's targets, restrictions and recent foods. A
+// plan counts as one analysis against the user's quota.
+func (s *Service) CreateMealPlan(ctx context.Context, userID uuid.UUID, opts MealPlanOptions) (*domain.MealPlan, error) {
+	notes, err := normalizeMealPlanNotes(opts.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := opts.StartDate
+	if startDate.IsZero() {
+		startDate = time.Now().UTC()
+	}
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	targets, err := s.DailyTargets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if targets == nil {
+		return nil, domain.ErrTargetsRequired
+	}
+
+	restrictions, err := s.GetRestrictions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recents, err := s.Recents(ctx, userID, RecentsOptions{
+		Order: domain.RecentsOrderFrequent,
+		Limit: mealPlanRecentFoods,
+	})
+	if err != nil {
+		return nil, err
+	}
+	recentFoods := make([]string, 0, len(recents))
+	for _, recent := range recents {
+		recentFoods = append(recentFoods, recent.Entry.Name)
+	}
+
+	dates := make([]time.Time, 0, domain.MealPlanDays)
+	for i := range domain.MealPlanDays {
+		dates = append(dates, startDate.AddDate(0, 0, i))
+	}
+
+	days, err := s.planDays(ctx, userID, domain.MealPlanRequest{
+		Dates:        dates,
+		Targets:      *targets,
+		Restrictions: restrictions.Restrictions,
+		Notes:        notes,
+		RecentFoods:  recentFoods,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.mealPlanRepo.Create(ctx, domain.MealPlan{
+		UserID:       userID,
+		StartDate:    startDate,
+		Targets:      *targets,
+		Restrictions: restrictions.Restrictions,
+		Notes:        notes,
+		Days:         days,
+	})
+	if err != nil {
+		return nil, domain.WrapError("failed to save meal plan", err)
+	}
+
+	return plan, nil
+}
+
+func (s *Service) GetMealPlan(ctx context.Context, userID, id uuid.UUID) (*domain.MealPlan, error) {
+	plan, err := s.mealPlanRepo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, domain.WrapError("failed to get meal plan", err)
+	}
+
+	return plan, nil
+}
+
+// ListMealPlans returns the user's 20 most recently created plans
+func (s *Service) ListMealPlans(ctx context.Context, userID uuid.UUID) ([]domain.MealPlan, error) {
+	plans, err := s.mealPlanRepo.List(ctx, userID, defaultMealPlanLimit)
+	if err != nil {
+		return nil, domain.WrapError("failed to list meal plans", err)
+	}
+
+	return plans, nil
+}
+
+// RegenerateMealPlanDay replaces the meals of one date of a plan, planned for
+// the targets and restrictions the plan was created with and avoiding the
+// meals of its other days
+func (s *Service) RegenerateMealPlanDay(ctx context.Context, userID, id uuid.UUID, date time.Time) (*domain.MealPlan, error) {
+	plan, err := s.GetMealPlan(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(plan.Days, func(day domain.MealPlanDay) bool {
+		return day.Date.Equal(date)
+	})
+	if index < 0 {
+		return nil, domain.InvalidMealPlan("date", "is not a day of the meal plan")
+	}
+
+	var avoid []string
+	for i, day := range plan.Days {
+		if i == index {
+			continue
+		}
+		for _, meal := range day.Meals {
+			avoid = append(avoid, meal.Name)
+		}
+	}
+
+	days, err := s.planDays(ctx, userID, domain.MealPlanRequest{
+		Dates:        []time.Time{plan.Days[index].Date},
+		Targets:      plan.Targets,
+		Restrictions: plan.Restrictions,
+		Notes:        plan.Notes,
+		Avoid:        avoid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	plan.Days[index] = days[0]
+
+	updated, err := s.mealPlanRepo.UpdateDays(ctx, *plan)
+	if err != nil {
+		return nil, domain.WrapError("failed to save meal plan", err)
+	}
+
+	return updated, nil
+}
+
+// planDays checks the quota, plans the requested dates and records the usage.
+// Each day is stamped with what generated it.
+func (s *Service) planDays(ctx context.Context, userID uuid.UUID, req domain.MealPlanRequest) ([]domain.MealPlanDay, error) {
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	generated, err := s.planner.PlanMeals(ctx, req)
+	if err != nil {
+		s.recordFailedUsage(ctx, userID, domain.UsageKindMealPlan, err)
+		return nil, domain.WrapError("failed to generate meal plan", err)
+	}
+	s.recordUsage(ctx, userID, domain.UsageKindMealPlan, false, generated.Usage)
+
+	now := time.Now()
+	for i := range generated.Days {
+		generated.Days[i].GeneratedAt = now
+		generated.Days[i].Provenance = generated.Provenance
+	}
+
+	return generated.Days, nil
+}
+
+func normalizeMealPlanNotes(notes string) (string, error) {
+	notes = strings.TrimSpace(notes)
+	if utf8.RuneCountInString(notes) > maxMealPlanNotesLength {
+		return "", domain.InvalidMealPlan("notes", "must not exceed 500 characters")
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,84 @@
+package dietsvc
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// maxDayEntries bounds the entries grouped into one day's meals
+const maxDayEntries = 500
+
+type Meal struct {
+	Type domain.MealType
+	// Entries are in the order they were eaten
+	Entries []domain.DietEntry
+	Totals  domain.Nutrients
+}
+
+type DayMeals struct {
+	Date     time.Time
+	TimeZone string
+	// Meals has one meal per meal type in eating order, including empty ones
+	Meals  []Meal
+	Totals domain.Nutrients
+}
+
+// Meals groups the user's entries on a local calendar date into meals. Only
+// the year, month and day of date are used. Entries logged before meal types
+// were recorded are placed by their local time in loc.
+func (s *Service) Meals(ctx context.Context, userID uuid.UUID, date time.Time, loc *time.Location) (*DayMeals, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 1)
+
+	entries, err := s.entryRepo.List(ctx, userID, domain.EntryFilter{
+		From:  &from,
+		To:    &to,
+		Limit: maxDayEntries,
+	})
+	if err != nil {
+		return nil, domain.WrapError("failed to list diet entries", err)
+	}
+	// List is newest first
+	slices.Reverse(entries)
+
+	day := &DayMeals{
+		Date:     from,
+		TimeZone: loc.String(),
+		Meals:    make([]Meal, len(domain.MealTypes)),
+	}
+	for i, mealType := range domain.MealTypes {
+		day.Meals[i] = Meal{Type: mealType, Entries: []domain.DietEntry{}}
+	}
+
+	for _, entry := range entries {
+		mealType := entry.MealType
+		if mealType == "" {
+			mealType = domain.MealTypeAt(entry.EatenAt.In(loc))
+		}
+
+		i := slices.Index(domain.MealTypes, mealType)
+		if i < 0 {
+			continue
+		}
+
+		nutrients := domain.Nutrients{
+			Calories: entry.Calories,
+			Protein:  entry.Protein,
+			Fat:      entry.Fat,
+			Carbs:    entry.Carbs,
+		}
+		day.Meals[i].Entries = append(day.Meals[i].Entries, entry)
+		day.Meals[i].Totals = day.Meals[i].Totals.Add(nutrients)
+		day.Totals = day.Totals.Add(nutrients)
+	}
+
+	return day, nil
+}
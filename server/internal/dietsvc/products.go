@@ -0,0 +1,74 @@
+package dietsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// ErrInvalidProduct marks catalog rows rejected by ImportProduct, so imports
+// can skip them and still stop on database errors
+var ErrInvalidProduct = errors.New("invalid product")
+
+// LookupBarcode returns the label nutrition for one serving of the product
+// with the given barcode
+func (s *Service) LookupBarcode(ctx context.Context, code string) (*domain.DietAnalysis, error) {
+	barcode, ok := domain.NormalizeBarcode(code)
+	if !ok {
+		return nil, domain.ErrInvalidBarcode
+	}
+
+	product, err := s.productRepo.Get(ctx, barcode)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, domain.WrapError("failed to look up product", err)
+	}
+
+	return product.Analysis(), nil
+}
+
+// ImportProduct validates and upserts a catalog product under its normalized
+// barcode
+func (s *Service) ImportProduct(ctx context.Context, product domain.Product) error {
+	barcode, ok := domain.NormalizeBarcode(product.Barcode)
+	if !ok {
+		return fmt.Errorf("%w: barcode %q", ErrInvalidProduct, product.Barcode)
+	}
+	product.Barcode = barcode
+
+	if err := validateProduct(product); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidProduct, barcode, err)
+	}
+
+	if err := s.productRepo.Upsert(ctx, product); err != nil {
+		return fmt.Errorf("failed to import product %s: %w", barcode, err)
+	}
+
+	return nil
+}
+
+func validateProduct(product domain.Product) error {
+	if product.Source == "" {
+		return fmt.Errorf("source is required")
+	}
+	if strings.TrimSpace(product.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if product.ServingGrams != nil && (*product.ServingGrams <= 0 || *product.ServingGrams > maxServingGrams) {
+		return fmt.Errorf("serving size must be between 0 and %d grams", maxServingGrams)
+	}
+
+	// Products share the per-100 g limits of reference foods
+	return validateFood(domain.Food{
+		Source:         product.Source,
+		SourceID:       product.Barcode,
+		Name:           product.Name,
+		Nutrients:      product.Nutrients,
+		Micronutrients: product.Micronutrients,
+	})
+}
@@ -0,0 +1,188 @@
+package dietsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	maxRecipeServings    = 100
+	maxRecipeIngredients = 50
+	maxIngredientGrams   = 5000
+	maxIngredientUnits   = 100
+)
+
+// CreateRecipe saves a recipe, computing its nutrition from the foods of its
+// ingredients. Ingredients give either Grams, or a Serving label of the food
+// and a Quantity of it.
+func (s *Service) CreateRecipe(ctx context.Context, userID uuid.UUID, recipe domain.Recipe) (*domain.Recipe, error) {
+	recipe.UserID = userID
+	if err := s.buildRecipe(ctx, &recipe); err != nil {
+		return nil, err
+	}
+
+	created, err := s.recipeRepo.Create(ctx, recipe)
+	if err != nil {
+		return nil, domain.WrapError("failed to create recipe", err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetRecipe(ctx context.Context, userID, id uuid.UUID) (*domain.Recipe, error) {
+	recipe, err := s.recipeRepo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, domain.WrapError("failed to get recipe", err)
+	}
+
+	return recipe, nil
+}
+
+func (s *Service) ListRecipes(ctx context.Context, userID uuid.UUID) ([]domain.Recipe, error) {
+	recipes, err := s.recipeRepo.List(ctx, userID)
+	if err != nil {
+		return nil, domain.WrapError("failed to list recipes", err)
+	}
+
+	return recipes, nil
+}
+
+// UpdateRecipe replaces a recipe and recomputes its nutrition from the
+// current food values. Entries already logged from it keep their values.
+func (s *Service) UpdateRecipe(ctx context.Context, userID uuid.UUID, recipe domain.Recipe) (*domain.Recipe, error) {
+	recipe.UserID = userID
+	if err := s.buildRecipe(ctx, &recipe); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.recipeRepo.Update(ctx, recipe)
+	if err != nil {
+		return nil, domain.WrapError("failed to update recipe", err)
+	}
+
+	return updated, nil
+}
+
+func (s *Service) DeleteRecipe(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.recipeRepo.Delete(ctx, userID, id); err != nil {
+		return domain.WrapError("failed to delete recipe", err)
+	}
+
+	return nil
+}
+
+// LogRecipe creates a diet entry with the exact nutrition of some servings
+// of a recipe, without analyzing anything
+func (s *Service) LogRecipe(ctx context.Context, userID, id uuid.UUID, opts LogOptions) (*domain.DietEntry, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	recipe, err := s.GetRecipe(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	nutrients, micronutrients := recipe.PerServing(opts.Servings)
+	return s.CreateEntry(ctx, userID, domain.DietEntry{
+		Name:           recipe.Name,
+		Description:    fmt.Sprintf("%g of %g servings", opts.Servings, recipe.Servings),
+		Calories:       nutrients.Calories,
+		Protein:        nutrients.Protein,
+		Fat:            nutrients.Fat,
+		Carbs:          nutrients.Carbs,
+		EatenAt:        opts.EatenAt,
+		Source:         domain.EntrySourceRecipe,
+		MealType:       opts.MealType,
+		Micronutrients: micronutrients,
+	})
+}
+
+// buildRecipe validates the recipe, resolves each ingredient's amount in
+// grams and computes the nutrition from the food database
+func (s *Service) buildRecipe(ctx context.Context, recipe *domain.Recipe) error {
+	recipe.Name = strings.TrimSpace(recipe.Name)
+	recipe.Description = strings.TrimSpace(recipe.Description)
+
+	if recipe.Name == "" {
+		return domain.InvalidRecipe("name", "is required")
+	}
+	if utf8.RuneCountInString(recipe.Name) > maxEntryNameLength {
+		return domain.InvalidRecipe("name", "must not exceed 200 characters")
+	}
+	if utf8.RuneCountInString(recipe.Description) > maxDescriptionLength {
+		return domain.InvalidRecipe("description", "must not exceed 1000 characters")
+	}
+	if !isValidAmount(recipe.Servings, maxRecipeServings) || recipe.Servings <= 0 {
+		return domain.InvalidRecipe("servings", "must be greater than 0 and at most 100")
+	}
+	if len(recipe.Ingredients) == 0 {
+		return domain.InvalidRecipe("ingredients", "must not be empty")
+	}
+	if len(recipe.Ingredients) > maxRecipeIngredients {
+		return domain.InvalidRecipe("ingredients", "must not exceed 50 items")
+	}
+
+	foods := make(map[uuid.UUID]*domain.Food)
+	for i := range recipe.Ingredients {
+		ingredient := &recipe.Ingredients[i]
+		field := fmt.Sprintf("ingredients[%d]", i)
+
+		food, ok := foods[ingredient.FoodID]
+		if !ok {
+			var err error
+			food, err = s.foodRepo.Get(ctx, ingredient.FoodID)
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return domain.InvalidRecipe(field+".food_id", "is not a known food")
+				}
+				return domain.WrapError("failed to get recipe food", err)
+			}
+			foods[ingredient.FoodID] = food
+		}
+
+		if err := resolveIngredientGrams(ingredient, food, field); err != nil {
+			return err
+		}
+		ingredient.ComputeNutrition(*food)
+	}
+	recipe.SumIngredients()
+
+	return nil
+}
+
+// resolveIngredientGrams sets Grams from the food's serving when the amount
+// was given as a household measure
+func resolveIngredientGrams(ingredient *domain.RecipeIngredient, food *domain.Food, field string) error {
+	ingredient.Serving = strings.TrimSpace(ingredient.Serving)
+	if ingredient.Serving == "" {
+		ingredient.Quantity = 0
+		if !isValidAmount(ingredient.Grams, maxIngredientGrams) || ingredient.Grams <= 0 {
+			return domain.InvalidRecipe(field+".grams", "must be greater than 0 and at most 5000")
+		}
+		return nil
+	}
+
+	if ingredient.Quantity == 0 {
+		ingredient.Quantity = 1
+	}
+	if !isValidAmount(ingredient.Quantity, maxIngredientUnits) || ingredient.Quantity <= 0 {
+		return domain.InvalidRecipe(field+".quantity", "must be greater than 0 and at most 100")
+	}
+
+	for _, serving := range food.Servings {
+		if strings.EqualFold(serving.Label, ingredient.Serving) {
+			ingredient.Serving = serving.Label
+			ingredient.Grams = serving.Grams * ingredient.Quantity
+			return nil
+		}
+	}
+
+	return domain.InvalidRecipe(field+".serving", fmt.Sprintf("is not a serving of %s", food.Name))
+}
@@ -0,0 +1,69 @@
+package dietsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// GetRestrictions returns the user's declared restrictions, which are empty
+// when the user has not declared any
+func (s *Service) GetRestrictions(ctx context.Context, userID uuid.UUID) (*domain.UserRestrictions, error) {
+	restrictions, err := s.restrictionRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &domain.UserRestrictions{UserID: userID, Restrictions: []domain.Restriction{}}, nil
+		}
+		return nil, domain.WrapError("failed to get dietary restrictions", err)
+	}
+
+	return restrictions, nil
+}
+
+// UpdateRestrictions replaces the user's restrictions. An empty list clears
+// them.
+func (s *Service) UpdateRestrictions(ctx context.Context, userID uuid.UUID, restrictions []domain.Restriction) (*domain.UserRestrictions, error) {
+	normalized := make([]domain.Restriction, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		if !restriction.IsValid() {
+			return nil, domain.InvalidRestrictions(fmt.Sprintf("contains unknown restriction %q", restriction))
+		}
+		if !slices.Contains(normalized, restriction) {
+			normalized = append(normalized, restriction)
+		}
+	}
+	slices.Sort(normalized)
+
+	updated, err := s.restrictionRepo.Upsert(ctx, domain.UserRestrictions{
+		UserID:       userID,
+		Restrictions: normalized,
+	})
+	if err != nil {
+		return nil, domain.WrapError("failed to save dietary restrictions", err)
+	}
+
+	return updated, nil
+}
+
+// flagRestrictions sets the analysis warnings from the user's restrictions.
+// A failed lookup leaves the analysis unchecked rather than failing an
+// analysis that was already paid for.
+func (s *Service) flagRestrictions(ctx context.Context, userID uuid.UUID, analysis *domain.DietAnalysis) {
+	restrictions, err := s.restrictionRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			analysis.RestrictionsChecked = true
+			return
+		}
+		slog.Error("failed to get dietary restrictions", "user_id", userID, "error", err)
+		return
+	}
+
+	analysis.Warnings = domain.CheckRestrictions(analysis.Items, restrictions.Restrictions)
+	analysis.RestrictionsChecked = true
+}
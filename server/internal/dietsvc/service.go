@@ -2,55 +2,316 @@ package dietsvc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
-	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/openai"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/supporting/imageproc"
 )
 
-const maxImageSize = 5 * 1024 * 1024
+const (
+	maxImageSize         = 5 * 1024 * 1024
+	maxDescriptionLength = 1000
+	maxHintLength        = 200
+	maxHintServings      = 20
+)
 
 type Service struct {
-	visionClient *openai.VisionClient
+	analyzer         domain.FoodAnalyzer
+	imageProcessor   *imageproc.Processor
+	cache            domain.AnalysisCache
+	cacheTTL         time.Duration
+	entryRepo        domain.EntryRepository
+	settingsRepo     domain.SettingsRepository
+	foodRepo         domain.FoodRepository
+	productRepo      domain.ProductRepository
+	jobRepo          domain.JobRepository
+	notifier         domain.JobNotifier
+	jobRetention     time.Duration
+	jobTimeout       time.Duration
+	jobWake          chan struct{}
+	batchConcurrency int
+	usageRepo        domain.UsageRepository
+	quota            Quota
+	analysisRepo     domain.AnalysisRepository
+	restrictionRepo  domain.RestrictionRepository
+	recipeRepo       domain.RecipeRepository
+	favoriteRepo     domain.FavoriteRepository
+	planner          domain.MealPlanner
+	mealPlanRepo     domain.MealPlanRepository
+}
+
+type ServiceConfig struct {
+	Analyzer       domain.FoodAnalyzer
+	ImageProcessor *imageproc.Processor
+	// Cache is optional; analyses are not cached when it is nil
+	Cache              domain.AnalysisCache
+	CacheTTL           time.Duration
+	EntryRepository    domain.EntryRepository
+	SettingsRepository domain.SettingsRepository
+	FoodRepository     domain.FoodRepository
+	ProductRepository  domain.ProductRepository
+	JobRepository      domain.JobRepository
+	// JobNotifier is optional; job callbacks are not sent when it is nil
+	JobNotifier domain.JobNotifier
+	// JobRetention is how long completed jobs are kept before cleanup
+	JobRetention time.Duration
+	// JobTimeout bounds one run of a job and should cover the analyzer's
+	// retries; defaults to 2 minutes
+	JobTimeout time.Duration
+	// BatchConcurrency limits concurrent analyses per batch request;
+	// defaults to 4
+	BatchConcurrency int
+	UsageRepository  domain.UsageRepository
+	// Quota limits uncached analyses per user; zero limits are unlimited
+	Quota                 Quota
+	AnalysisRepository    domain.AnalysisRepository
+	RestrictionRepository domain.RestrictionRepository
+	RecipeRepository      domain.RecipeRepository
+	FavoriteRepository    domain.FavoriteRepository
+	MealPlanner           domain.MealPlanner
+	MealPlanRepository    domain.MealPlanRepository
 }
 
-func NewService(visionClient *openai.VisionClient) *Service {
+func NewService(cfg ServiceConfig) *Service {
+	batchConcurrency := cfg.BatchConcurrency
+	if batchConcurrency < 1 {
+		batchConcurrency = defaultBatchConcurrency
+	}
+
+	jobTimeout := cfg.JobTimeout
+	if jobTimeout <= 0 {
+		jobTimeout = defaultJobTimeout
+	}
+
 	return &Service{
-		visionClient: visionClient,
+		analyzer:         cfg.Analyzer,
+		imageProcessor:   cfg.ImageProcessor,
+		cache:            cfg.Cache,
+		cacheTTL:         cfg.CacheTTL,
+		entryRepo:        cfg.EntryRepository,
+		settingsRepo:     cfg.SettingsRepository,
+		foodRepo:         cfg.FoodRepository,
+		productRepo:      cfg.ProductRepository,
+		jobRepo:          cfg.JobRepository,
+		notifier:         cfg.JobNotifier,
+		jobRetention:     cfg.JobRetention,
+		jobTimeout:       jobTimeout,
+		jobWake:          make(chan struct{}, 1),
+		batchConcurrency: batchConcurrency,
+		usageRepo:        cfg.UsageRepository,
+		quota:            cfg.Quota,
+		analysisRepo:     cfg.AnalysisRepository,
+		restrictionRepo:  cfg.RestrictionRepository,
+		recipeRepo:       cfg.RecipeRepository,
+		favoriteRepo:     cfg.FavoriteRepository,
+		planner:          cfg.MealPlanner,
+		mealPlanRepo:     cfg.MealPlanRepository,
+	}
+}
+
+type AnalyzeFoodOptions struct {
+	// UserID is charged for the analysis
+	UserID    uuid.UUID
+	ImageData []byte
+	Hints     domain.AnalysisHints
+	// BypassCache forces a fresh analysis; the result still refreshes the cache
+	BypassCache bool
+}
+
+type AnalyzeFoodResult struct {
+	Analysis *domain.DietAnalysis
+	Cached   bool
+}
+
+func (s *Service) AnalyzeFood(ctx context.Context, opts AnalyzeFoodOptions) (*AnalyzeFoodResult, error) {
+	imageData, hints, err := s.prepareImage(opts.ImageData, opts.Hints)
+	if err != nil {
+		return nil, err
 	}
+
+	return s.analyzeImage(ctx, opts.UserID, imageData, hints, opts.BypassCache)
 }
 
-func (s *Service) AnalyzeFood(ctx context.Context, imageData []byte, mimeType string) (*domain.DietAnalysis, error) {
-	if len(imageData) > maxImageSize {
-		return nil, domain.ErrImageTooLarge
+// prepareImage validates the upload and hints and normalizes the image, so
+// bad requests fail before any analysis is attempted
+func (s *Service) prepareImage(data []byte, hints domain.AnalysisHints) ([]byte, domain.AnalysisHints, error) {
+	if len(data) > maxImageSize {
+		return nil, hints, domain.ErrImageTooLarge
 	}
 
-	if !isValidImageType(mimeType) {
-		return nil, domain.ErrInvalidImage
+	hints, err := normalizeHints(hints)
+	if err != nil {
+		return nil, hints, err
 	}
 
-	analysis, err := s.visionClient.AnalyzeFood(ctx, imageData, mimeType)
+	imageData, err := s.imageProcessor.Process(data)
 	if err != nil {
+		return nil, hints, domain.WrapError("failed to process food image", err)
+	}
+
+	return imageData, hints, nil
+}
+
+// analyzeImage analyzes an image already normalized by prepareImage and
+// records the usage against userID. The quota is only checked on a cache
+// miss, since cached results are free.
+func (s *Service) analyzeImage(ctx context.Context, userID uuid.UUID, imageData []byte, hints domain.AnalysisHints, bypassCache bool) (*AnalyzeFoodResult, error) {
+	key := cacheKey(s.analyzer.Provenance(), imageData, hints)
+	if s.cache != nil && !bypassCache {
+		analysis, err := s.cache.Get(ctx, key)
+		if err == nil {
+			slog.Info("analysis cache hit", "key", key)
+			s.recordUsage(ctx, userID, domain.UsageKindImage, true, domain.TokenUsage{})
+			analysis.MealType = domain.ResolveMealType(hints.MealType, analysis.MealType, hints.LocalTime)
+			s.flagRestrictions(ctx, userID, analysis)
+			s.storeAnalysis(ctx, userID, domain.JobKindImage, true, analysis)
+			return &AnalyzeFoodResult{Analysis: analysis, Cached: true}, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			slog.Error("failed to read analysis cache", "error", err)
+		}
+	}
+
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	analysis, err := s.analyzer.AnalyzeFood(ctx, imageData, imageproc.OutputMimeType, hints)
+	if err != nil {
+		s.recordFailedUsage(ctx, userID, domain.UsageKindImage, err)
 		return nil, domain.WrapError("failed to analyze food image", err)
 	}
+	s.recordUsage(ctx, userID, domain.UsageKindImage, false, analysis.Usage)
+
+	if !analysis.IsFood {
+		return nil, domain.ErrNotFood
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, key, *analysis, time.Now().Add(s.cacheTTL)); err != nil {
+			slog.Error("failed to write analysis cache", "error", err)
+		}
+	}
+	// Resolved after caching, since the cached analysis is shared by users
+	// with other choices, times and restrictions
+	analysis.MealType = domain.ResolveMealType(hints.MealType, analysis.MealType, hints.LocalTime)
+	s.flagRestrictions(ctx, userID, analysis)
+	s.storeAnalysis(ctx, userID, domain.JobKindImage, false, analysis)
+
+	return &AnalyzeFoodResult{Analysis: analysis}, nil
+}
+
+// AnalyzeFoodText analyzes a meal description. Only the meal type and local
+// time hints apply to text analyses.
+func (s *Service) AnalyzeFoodText(ctx context.Context, userID uuid.UUID, description string, hints domain.AnalysisHints) (*domain.DietAnalysis, error) {
+	description, err := normalizeDescription(description)
+	if err != nil {
+		return nil, err
+	}
+
+	if hints, err = normalizeHints(hints); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.analyzeText(ctx, userID, description, hints)
+}
+
+func (s *Service) analyzeText(ctx context.Context, userID uuid.UUID, description string, hints domain.AnalysisHints) (*domain.DietAnalysis, error) {
+	analysis, err := s.analyzer.AnalyzeFoodText(ctx, description)
+	if err != nil {
+		s.recordFailedUsage(ctx, userID, domain.UsageKindText, err)
+		return nil, domain.WrapError("failed to analyze meal description", err)
+	}
+	s.recordUsage(ctx, userID, domain.UsageKindText, false, analysis.Usage)
+
+	if !analysis.IsFood {
+		return nil, domain.ErrNotFood
+	}
+	analysis.MealType = domain.ResolveMealType(hints.MealType, analysis.MealType, hints.LocalTime)
+	s.flagRestrictions(ctx, userID, analysis)
+	s.storeAnalysis(ctx, userID, domain.JobKindText, false, analysis)
 
 	return analysis, nil
 }
 
-func isValidImageType(mimeType string) bool {
-	validTypes := []string{
-		"image/jpeg",
-		"image/jpg",
-		"image/png",
-		"image/webp",
+func normalizeDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return "", domain.ErrNoDescription
+	}
+
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return "", domain.ErrDescriptionTooLong
+	}
+
+	return description, nil
+}
+
+// CleanupExpired removes expired cache entries and old completed jobs
+func (s *Service) CleanupExpired(ctx context.Context) error {
+	if s.cache != nil {
+		if err := s.cache.DeleteExpired(ctx); err != nil {
+			slog.Error("failed to delete expired cached analyses", "error", err)
+		}
 	}
 
-	mimeType = strings.ToLower(mimeType)
-	for _, validType := range validTypes {
-		if mimeType == validType {
-			return true
+	if s.jobRepo != nil && s.jobRetention > 0 {
+		if err := s.jobRepo.DeleteCompletedBefore(ctx, time.Now().Add(-s.jobRetention)); err != nil {
+			slog.Error("failed to delete completed analysis jobs", "error", err)
 		}
 	}
 
-	return false
+	return nil
+}
+
+// cacheKey hashes the normalized image together with the analyzer's model
+// and prompt version and any hints, since each changes the analysis result
+func cacheKey(provenance domain.Provenance, imageData []byte, hints domain.AnalysisHints) string {
+	h := sha256.New()
+	h.Write(imageData)
+	fmt.Fprintf(h, "\x00%s\x00%s", provenance.Model, provenance.PromptVersion)
+	if !hints.IsEmpty() {
+		fmt.Fprintf(h, "\x00%s\x00%g\x00%s\x00%s\x00%s",
+			hints.PortionSize, hints.Servings, hints.Cuisine, hints.MealType, hints.Notes)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func normalizeHints(hints domain.AnalysisHints) (domain.AnalysisHints, error) {
+	hints.PortionSize = strings.TrimSpace(hints.PortionSize)
+	hints.Cuisine = strings.TrimSpace(hints.Cuisine)
+	hints.MealType = domain.MealType(strings.ToLower(strings.TrimSpace(string(hints.MealType))))
+	hints.Notes = strings.TrimSpace(hints.Notes)
+
+	for _, hint := range []struct{ field, value string }{
+		{"portion", hints.PortionSize},
+		{"cuisine", hints.Cuisine},
+		{"notes", hints.Notes},
+	} {
+		if utf8.RuneCountInString(hint.value) > maxHintLength {
+			return hints, domain.InvalidHints(hint.field, "must not exceed 200 characters")
+		}
+	}
+
+	if hints.Servings < 0 || hints.Servings > maxHintServings {
+		return hints, domain.InvalidHints("servings", "must be between 0 and 20")
+	}
+
+	if hints.MealType != "" && !hints.MealType.IsValid() {
+		return hints, domain.InvalidHints("meal_type", "must be one of breakfast, lunch, dinner or snack")
+	}
+
+	return hints, nil
 }
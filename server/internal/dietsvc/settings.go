@@ -0,0 +1,122 @@
+package dietsvc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	minHeightCm = 50
+	maxHeightCm = 275
+	minWeightKg = 20
+	maxWeightKg = 500
+	minAge      = 13
+	maxAge      = 120
+)
+
+// UpdateSettingsOptions sets targets manually, calculates them from a body
+// profile, or both: manual targets win and the profile is still saved
+type UpdateSettingsOptions struct {
+	Targets *domain.Nutrients
+	Profile *domain.BodyProfile
+}
+
+func (s *Service) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	settings, err := s.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, domain.WrapError("failed to get nutrition settings", err)
+	}
+
+	return settings, nil
+}
+
+func (s *Service) UpdateSettings(ctx context.Context, userID uuid.UUID, opts UpdateSettingsOptions) (*domain.UserSettings, error) {
+	if opts.Targets == nil && opts.Profile == nil {
+		return nil, domain.InvalidSettings("targets", "or profile is required")
+	}
+
+	settings := domain.UserSettings{
+		UserID:  userID,
+		Profile: opts.Profile,
+	}
+
+	if opts.Profile != nil {
+		if err := validateProfile(*opts.Profile); err != nil {
+			return nil, err
+		}
+		settings.Targets = opts.Profile.CalculateTargets()
+		settings.TargetSource = domain.TargetSourceCalculated
+	}
+
+	if opts.Targets != nil {
+		if err := validateTargets(*opts.Targets); err != nil {
+			return nil, err
+		}
+		settings.Targets = *opts.Targets
+		settings.TargetSource = domain.TargetSourceManual
+	}
+
+	updated, err := s.settingsRepo.Upsert(ctx, settings)
+	if err != nil {
+		return nil, domain.WrapError("failed to save nutrition settings", err)
+	}
+
+	return updated, nil
+}
+
+// DailyTargets returns the user's daily nutrition targets, or nil when none
+// have been set
+func (s *Service) DailyTargets(ctx context.Context, userID uuid.UUID) (*domain.Nutrients, error) {
+	settings, err := s.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, domain.WrapError("failed to get nutrition targets", err)
+	}
+
+	return &settings.Targets, nil
+}
+
+func validateTargets(targets domain.Nutrients) error {
+	if targets.Calories <= 0 || !isValidAmount(targets.Calories, maxEntryCalories) {
+		return domain.InvalidSettings("calories", "must be greater than 0 and at most 10000")
+	}
+	for _, macro := range []struct {
+		field string
+		value float64
+	}{
+		{"protein", targets.Protein},
+		{"fat", targets.Fat},
+		{"carbs", targets.Carbs},
+	} {
+		if !isValidAmount(macro.value, maxEntryMacroGrams) {
+			return domain.InvalidSettings(macro.field, "must be between 0 and 1000")
+		}
+	}
+
+	return nil
+}
+
+func validateProfile(profile domain.BodyProfile) error {
+	if profile.HeightCm < minHeightCm || profile.HeightCm > maxHeightCm {
+		return domain.InvalidSettings("height_cm", "must be between 50 and 275")
+	}
+	if profile.WeightKg < minWeightKg || profile.WeightKg > maxWeightKg {
+		return domain.InvalidSettings("weight_kg", "must be between 20 and 500")
+	}
+	if profile.Age < minAge || profile.Age > maxAge {
+		return domain.InvalidSettings("age", "must be between 13 and 120")
+	}
+	if !profile.Sex.IsValid() {
+		return domain.InvalidSettings("sex", "must be male or female")
+	}
+	if !profile.ActivityLevel.IsValid() {
+		return domain.InvalidSettings("activity_level", "must be one of sedentary, light, moderate, active or very_active")
+	}
+
+	return nil
+}
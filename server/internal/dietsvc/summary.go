@@ -0,0 +1,171 @@
+package dietsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+const (
+	maxSummaryDays  = 366
+	maxSummaryWeeks = 104
+)
+
+// SummaryOptions selects an inclusive range of local calendar dates. From and
+// To only use their year, month and day.
+type SummaryOptions struct {
+	From        time.Time
+	To          time.Time
+	Granularity domain.Granularity
+	Location    *time.Location
+}
+
+type NutritionSummary struct {
+	From        time.Time
+	To          time.Time
+	Granularity domain.Granularity
+	TimeZone    string
+	Totals      domain.Nutrients
+	// PeriodAverages are the totals divided by the number of periods in range
+	PeriodAverages domain.Nutrients
+	Periods        []domain.PeriodSummary
+	// Targets are the user's daily targets and Progress compares Totals with
+	// them over the whole range; both are nil when the user has no targets
+	Targets  *domain.Nutrients
+	Progress *domain.Progress
+}
+
+func (s *Service) Summary(ctx context.Context, userID uuid.UUID, opts SummaryOptions) (*NutritionSummary, error) {
+	if opts.Granularity == "" {
+		opts.Granularity = domain.GranularityDay
+	}
+	if !opts.Granularity.IsValid() {
+		return nil, domain.InvalidSummary("granularity", "must be day or week")
+	}
+
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// Midnight at the start of From and the day after To in the user's zone
+	from := time.Date(opts.From.Year(), opts.From.Month(), opts.From.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(opts.To.Year(), opts.To.Month(), opts.To.Day()+1, 0, 0, 0, 0, loc)
+
+	if !from.Before(to) {
+		return nil, domain.InvalidSummary("from", "must not be after to")
+	}
+
+	days := int(to.Sub(from).Hours()/24 + 0.5)
+	if opts.Granularity == domain.GranularityDay && days > maxSummaryDays {
+		return nil, domain.InvalidSummary("to", "range must not exceed 366 days")
+	}
+	if opts.Granularity == domain.GranularityWeek && days > maxSummaryWeeks*7 {
+		return nil, domain.InvalidSummary("to", "range must not exceed 104 weeks")
+	}
+
+	periods, err := s.entryRepo.Summarize(ctx, domain.SummaryQuery{
+		UserID:      userID,
+		From:        from,
+		To:          to,
+		Granularity: opts.Granularity,
+		TimeZone:    loc.String(),
+	})
+	if err != nil {
+		return nil, domain.WrapError("failed to summarize diet entries", err)
+	}
+
+	targets, err := s.DailyTargets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &NutritionSummary{
+		From:        from,
+		To:          to.AddDate(0, 0, -1),
+		Granularity: opts.Granularity,
+		TimeZone:    loc.String(),
+		Periods:     periods,
+		Targets:     targets,
+	}
+
+	for i, period := range periods {
+		summary.Totals = summary.Totals.Add(period.Totals)
+
+		if targets != nil {
+			periodTargets := targets.Scale(float64(periodDays(period.Start, opts.Granularity, from, to)))
+			progress := domain.NewProgress(periodTargets, period.Totals)
+			summary.Periods[i].Progress = &progress
+		}
+	}
+
+	if targets != nil {
+		progress := domain.NewProgress(targets.Scale(float64(days)), summary.Totals)
+		summary.Progress = &progress
+	}
+
+	if n := float64(len(periods)); n > 0 {
+		summary.PeriodAverages = summary.Totals.Scale(1 / n)
+	}
+
+	return summary, nil
+}
+
+// periodDays counts the days of the period starting at start that fall inside
+// the summary range, since the first and last weeks may be partial
+func periodDays(start time.Time, granularity domain.Granularity, from, to time.Time) int {
+	// Period starts are local dates; anchor them in the range's zone
+	begin := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, from.Location())
+	end := begin.AddDate(0, 0, 1)
+	if granularity == domain.GranularityWeek {
+		end = begin.AddDate(0, 0, 7)
+	}
+
+	if begin.Before(from) {
+		begin = from
+	}
+	if end.After(to) {
+		end = to
+	}
+
+	return max(0, int(end.Sub(begin).Hours()/24+0.5))
+}
+
+// DayTotals sums everything the user logged on each local date in loc that
+// one of entries was eaten on, keyed by YYYY-MM-DD date
+func (s *Service) DayTotals(ctx context.Context, userID uuid.UUID, entries []domain.DietEntry, loc *time.Location) (map[string]domain.Nutrients, error) {
+	totals := make(map[string]domain.Nutrients)
+	if len(entries) == 0 {
+		return totals, nil
+	}
+
+	first, last := entries[0].EatenAt, entries[0].EatenAt
+	for _, entry := range entries[1:] {
+		if entry.EatenAt.Before(first) {
+			first = entry.EatenAt
+		}
+		if entry.EatenAt.After(last) {
+			last = entry.EatenAt
+		}
+	}
+	first, last = first.In(loc), last.In(loc)
+
+	periods, err := s.entryRepo.Summarize(ctx, domain.SummaryQuery{
+		UserID:      userID,
+		From:        time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc),
+		To:          time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc),
+		Granularity: domain.GranularityDay,
+		TimeZone:    loc.String(),
+	})
+	if err != nil {
+		return nil, domain.WrapError("failed to summarize diet entries", err)
+	}
+
+	for _, period := range periods {
+		totals[period.Start.Format(time.DateOnly)] = period.Totals
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,207 @@
+package fake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// Fixture is the on-disk representation of a canned analysis result. IsFood
+// and Confidence default to true and 1 when omitted.
+type Fixture struct {
+	FoodName   string        `json:"food_name"`
+	Items      []FixtureItem `json:"items"`
+	Calories   float64       `json:"calories"`
+	Protein    float64       `json:"protein"`
+	Fat        float64       `json:"fat"`
+	Carbs      float64       `json:"carbs"`
+	IsFood     *bool         `json:"is_food"`
+	Confidence *float64      `json:"confidence"`
+	// MealType is the detected meal type; omitted means not detected
+	MealType string `json:"meal_type"`
+	// Micronutrient totals are only used when the fixture has no items
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+}
+
+type FixtureItem struct {
+	Name         string   `json:"name"`
+	PortionGrams float64  `json:"portion_grams"`
+	Servings     float64  `json:"servings"`
+	Calories     float64  `json:"calories"`
+	Protein      float64  `json:"protein"`
+	Fat          float64  `json:"fat"`
+	Carbs        float64  `json:"carbs"`
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+	Ingredients  []string `json:"ingredients"`
+	Allergens    []string `json:"allergens"`
+	Diet         string   `json:"diet"`
+}
+
+// FixtureAnalyzer is an offline FoodAnalyzer that returns canned results
+// keyed by the SHA-256 hash of the image bytes or meal description
+type FixtureAnalyzer struct {
+	fixtures map[string]Fixture
+	fallback Fixture
+}
+
+var _ domain.FoodAnalyzer = (*FixtureAnalyzer)(nil)
+
+// Model and PromptVersion are reported as the provenance of every fixture
+// analysis, which costs nothing
+const (
+	Model         = "fake"
+	PromptVersion = "fixture"
+)
+
+var defaultFixture = Fixture{
+	FoodName: "Rice, dal and salad",
+	Items: []FixtureItem{
+		{
+			Name: "Steamed rice", PortionGrams: 150, Servings: 1, Calories: 195, Protein: 4, Fat: 0.5, Carbs: 43,
+			Fiber: ptr(0.6), Sugar: ptr(0.1), SaturatedFat: ptr(0.1), SodiumMg: ptr(2),
+			Ingredients: []string{"rice", "water"}, Diet: "vegan",
+		},
+		{
+			Name: "Dal", PortionGrams: 200, Servings: 1, Calories: 230, Protein: 14, Fat: 6, Carbs: 30,
+			Fiber: ptr(8), Sugar: ptr(2), SaturatedFat: ptr(2.5), SodiumMg: ptr(480),
+			Ingredients: []string{"lentils", "ghee", "onion", "tomato", "spices"}, Allergens: []string{"milk"}, Diet: "vegetarian",
+		},
+		{
+			Name: "Green salad", PortionGrams: 100, Servings: 1, Calories: 35, Protein: 2, Fat: 0.5, Carbs: 7,
+			Fiber: ptr(2.5), Sugar: ptr(3.5), SaturatedFat: ptr(0.1), SodiumMg: ptr(30),
+			Ingredients: []string{"lettuce", "cucumber", "tomato", "lemon juice"}, Diet: "vegan",
+		},
+	},
+}
+
+func NewFixtureAnalyzer(fixtures map[string]Fixture) *FixtureAnalyzer {
+	if fixtures == nil {
+		fixtures = map[string]Fixture{}
+	}
+
+	fallback := defaultFixture
+	if f, ok := fixtures["default"]; ok {
+		fallback = f
+	}
+
+	return &FixtureAnalyzer{
+		fixtures: fixtures,
+		fallback: fallback,
+	}
+}
+
+// LoadFixtures reads a JSON object mapping hex-encoded SHA-256 input hashes to
+// fixtures. The optional "default" key is returned for unknown images.
+func LoadFixtures(path string) (map[string]Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures file: %w", err)
+	}
+
+	var fixtures map[string]Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures file: %w", err)
+	}
+
+	return fixtures, nil
+}
+
+func (a *FixtureAnalyzer) AnalyzeFood(ctx context.Context, imageData []byte, mimeType string, hints domain.AnalysisHints) (*domain.DietAnalysis, error) {
+	return a.lookup(imageData), nil
+}
+
+func (a *FixtureAnalyzer) AnalyzeFoodText(ctx context.Context, description string) (*domain.DietAnalysis, error) {
+	return a.lookup([]byte(description)), nil
+}
+
+func (a *FixtureAnalyzer) Provenance() domain.Provenance {
+	return domain.Provenance{Model: Model, PromptVersion: PromptVersion}
+}
+
+func (a *FixtureAnalyzer) lookup(input []byte) *domain.DietAnalysis {
+	fixture, ok := a.fixtures[Hash(input)]
+	if !ok {
+		fixture = a.fallback
+	}
+
+	analysis := toDomainAnalysis(fixture)
+	analysis.Provenance = a.Provenance()
+	analysis.Usage = domain.TokenUsage{Model: Model}
+
+	return analysis
+}
+
+// Hash returns the fixture key for the given image bytes or description
+func Hash(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+func toDomainAnalysis(f Fixture) *domain.DietAnalysis {
+	analysis := &domain.DietAnalysis{
+		FoodName:   f.FoodName,
+		Items:      make([]domain.FoodItem, 0, len(f.Items)),
+		Calories:   f.Calories,
+		Protein:    f.Protein,
+		Fat:        f.Fat,
+		Carbs:      f.Carbs,
+		IsFood:     true,
+		Confidence: 1,
+		MealType:   domain.MealType(f.MealType),
+		Micronutrients: domain.Micronutrients{
+			Fiber:        f.Fiber,
+			Sugar:        f.Sugar,
+			SaturatedFat: f.SaturatedFat,
+			SodiumMg:     f.SodiumMg,
+		},
+	}
+	if f.IsFood != nil {
+		analysis.IsFood = *f.IsFood
+	}
+	if f.Confidence != nil {
+		analysis.Confidence = *f.Confidence
+	}
+	for _, item := range f.Items {
+		allergens := make([]domain.Allergen, 0, len(item.Allergens))
+		for _, allergen := range item.Allergens {
+			allergens = append(allergens, domain.Allergen(allergen))
+		}
+
+		analysis.Items = append(analysis.Items, domain.FoodItem{
+			Name:         item.Name,
+			PortionGrams: item.PortionGrams,
+			Servings:     item.Servings,
+			Calories:     item.Calories,
+			Protein:      item.Protein,
+			Fat:          item.Fat,
+			Carbs:        item.Carbs,
+			Ingredients:  item.Ingredients,
+			Allergens:    allergens,
+			Diet:         domain.Diet(item.Diet),
+			Micronutrients: domain.Micronutrients{
+				Fiber:        item.Fiber,
+				Sugar:        item.Sugar,
+				SaturatedFat: item.SaturatedFat,
+				SodiumMg:     item.SodiumMg,
+			},
+		})
+	}
+	analysis.SumItems()
+
+	return analysis
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,79 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// Planner is an offline MealPlanner. It plans the same vegan, allergen-free
+// meals for a date every time, splitting the targets 25/35/30/10 across
+// breakfast, lunch, dinner and a snack, so any restrictions are respected.
+type Planner struct{}
+
+var _ domain.MealPlanner = (*Planner)(nil)
+
+func NewPlanner() *Planner {
+	return &Planner{}
+}
+
+type plannedMeal struct {
+	name        string
+	ingredients []string
+}
+
+var plannedMeals = []struct {
+	mealType domain.MealType
+	share    float64
+	options  []plannedMeal
+}{
+	{domain.MealTypeBreakfast, 0.25, []plannedMeal{
+		{"Vegetable poha", []string{"rice flakes", "onion", "peas", "oil"}},
+		{"Banana chia pudding", []string{"chia seeds", "banana", "coconut milk"}},
+		{"Millet upma", []string{"foxtail millet", "carrot", "peas", "oil"}},
+	}},
+	{domain.MealTypeLunch, 0.35, []plannedMeal{
+		{"Rice, dal and salad", []string{"rice", "lentils", "tomato", "cucumber", "oil"}},
+		{"Chana masala with rice", []string{"chickpeas", "rice", "onion", "tomato", "oil"}},
+		{"Vegetable pulao", []string{"rice", "carrot", "beans", "peas", "oil"}},
+	}},
+	{domain.MealTypeDinner, 0.30, []plannedMeal{
+		{"Rajma with rice", []string{"kidney beans", "rice", "onion", "tomato", "oil"}},
+		{"Mixed vegetable curry with rice", []string{"potato", "cauliflower", "peas", "rice", "oil"}},
+		{"Lentil soup with potatoes", []string{"lentils", "potato", "carrot", "oil"}},
+	}},
+	{domain.MealTypeSnack, 0.10, []plannedMeal{
+		{"Fruit bowl", []string{"apple", "banana", "orange"}},
+		{"Roasted chickpeas", []string{"chickpeas", "oil", "spices"}},
+		{"Coconut water and an apple", []string{"coconut water", "apple"}},
+	}},
+}
+
+func (p *Planner) PlanMeals(ctx context.Context, req domain.MealPlanRequest) (*domain.GeneratedPlan, error) {
+	plan := &domain.GeneratedPlan{
+		Days:       make([]domain.MealPlanDay, 0, len(req.Dates)),
+		Provenance: p.Provenance(),
+		Usage:      domain.TokenUsage{Model: Model},
+	}
+	for _, date := range req.Dates {
+		day := domain.MealPlanDay{Date: date}
+		for _, planned := range plannedMeals {
+			meal := planned.options[date.YearDay()%len(planned.options)]
+			day.Meals = append(day.Meals, domain.PlannedMeal{
+				MealType:    planned.mealType,
+				Name:        meal.name,
+				Ingredients: meal.ingredients,
+				Diet:        domain.DietVegan,
+				Nutrients:   req.Targets.Scale(planned.share),
+			})
+		}
+		day.SumMeals()
+		plan.Days = append(plan.Days, day)
+	}
+
+	return plan, nil
+}
+
+func (p *Planner) Provenance() domain.Provenance {
+	return domain.Provenance{Model: Model, PromptVersion: PromptVersion}
+}
@@ -0,0 +1,147 @@
+package foodcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+var requiredColumns = []string{"id", "name", "calories", "protein", "fat", "carbs"}
+
+// Parse reads a food composition CSV with a header row. The id, name,
+// calories, protein, fat and carbs columns are required; category, fiber,
+// sugar, saturated_fat, sodium_mg and servings are optional. Nutrients are per
+// 100 g and an empty micronutrient cell means unknown. Servings are written as
+// "label:grams" pairs separated by semicolons, e.g. "1 cup:158;1 bowl:150".
+func Parse(r io.Reader, source string) ([]domain.Food, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	var foods []domain.Food
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		food, err := parseRecord(record, columns, source)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		foods = append(foods, *food)
+	}
+
+	return foods, nil
+}
+
+func parseRecord(record []string, columns map[string]int, source string) (*domain.Food, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	food := &domain.Food{
+		Source:   source,
+		SourceID: field("id"),
+		Name:     field("name"),
+		Category: field("category"),
+	}
+	if food.SourceID == "" {
+		return nil, fmt.Errorf("id is empty")
+	}
+
+	for _, n := range []struct {
+		name  string
+		value *float64
+	}{
+		{"calories", &food.Calories},
+		{"protein", &food.Protein},
+		{"fat", &food.Fat},
+		{"carbs", &food.Carbs},
+	} {
+		v, err := strconv.ParseFloat(field(n.name), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", n.name, err)
+		}
+		*n.value = v
+	}
+
+	for _, n := range []struct {
+		name  string
+		value **float64
+	}{
+		{"fiber", &food.Fiber},
+		{"sugar", &food.Sugar},
+		{"saturated_fat", &food.SaturatedFat},
+		{"sodium_mg", &food.SodiumMg},
+	} {
+		raw := field(n.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", n.name, err)
+		}
+		*n.value = &v
+	}
+
+	servings, err := parseServings(field("servings"))
+	if err != nil {
+		return nil, err
+	}
+	food.Servings = servings
+
+	return food, nil
+}
+
+func parseServings(raw string) ([]domain.Serving, error) {
+	var servings []domain.Serving
+	for _, part := range strings.Split(raw, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		label, grams, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid serving %q: expected label:grams", part)
+		}
+
+		g, err := strconv.ParseFloat(strings.TrimSpace(grams), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid serving %q: %w", part, err)
+		}
+
+		servings = append(servings, domain.Serving{Label: strings.TrimSpace(label), Grams: g})
+	}
+
+	return servings, nil
+}
@@ -0,0 +1,161 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"slices"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// OutputMimeType is the content type of every processed image
+const OutputMimeType = "image/jpeg"
+
+// maxPixels guards against decompression bombs: small files that decode to
+// enormous bitmaps
+const maxPixels = 50_000_000
+
+var supportedTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+type Config struct {
+	MaxDimension int
+	JPEGQuality  int
+}
+
+// Processor normalizes uploaded images before analysis: it applies EXIF
+// orientation, drops all metadata, downscales and re-encodes as JPEG
+type Processor struct {
+	maxDimension int
+	quality      int
+}
+
+func NewProcessor(cfg Config) *Processor {
+	quality := cfg.JPEGQuality
+	if quality <= 0 || quality > 100 {
+		quality = jpeg.DefaultQuality
+	}
+
+	return &Processor{
+		maxDimension: cfg.MaxDimension,
+		quality:      quality,
+	}
+}
+
+// Process returns the normalized JPEG encoding of data. Unsupported or
+// undecodable images return domain.ErrInvalidImage.
+func (p *Processor) Process(data []byte) ([]byte, error) {
+	// Sniff the real content type rather than trusting the upload header
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(supportedTypes, contentType) {
+		return nil, domain.ErrInvalidImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+
+	img := p.resize(src)
+
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.quality}); err != nil {
+		return nil, domain.WrapError("failed to encode image", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resize draws src onto an opaque white canvas, downscaling it so neither
+// side exceeds the configured maximum dimension
+func (p *Processor) resize(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if p.maxDimension > 0 && max(width, height) > p.maxDimension {
+		if width >= height {
+			height = max(1, height*p.maxDimension/width)
+			width = p.maxDimension
+		} else {
+			width = max(1, width*p.maxDimension/height)
+			height = p.maxDimension
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	if width == bounds.Dx() && height == bounds.Dy() {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	}
+
+	return dst
+}
+
+// orient transforms img so it displays upright for the given EXIF orientation
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= orientationNormal || orientation > 8 {
+		return img
+	}
+
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	// Orientations 5-8 swap width and height
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for dy := 0; dy < dh; dy++ {
+		for dx := 0; dx < dw; dx++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirror horizontal
+				sx, sy = w-1-dx, dy
+			case 3: // rotate 180
+				sx, sy = w-1-dx, h-1-dy
+			case 4: // mirror vertical
+				sx, sy = dx, h-1-dy
+			case 5: // transpose
+				sx, sy = dy, dx
+			case 6: // rotate 90 clockwise
+				sx, sy = dy, h-1-dx
+			case 7: // transverse
+				sx, sy = w-1-dy, h-1-dx
+			case 8: // rotate 90 counter-clockwise
+				sx, sy = w-1-dy, dx
+			}
+
+			si := img.PixOffset(sx, sy)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], img.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type cacheEntry struct {
+	analysis  domain.DietAnalysis
+	expiresAt time.Time
+}
+
+// AnalysisCache is a process-local domain.AnalysisCache
+type AnalysisCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+var _ domain.AnalysisCache = (*AnalysisCache)(nil)
+
+func NewAnalysisCache() *AnalysisCache {
+	return &AnalysisCache{
+		entries: map[string]cacheEntry{},
+	}
+}
+
+func (c *AnalysisCache) Get(ctx context.Context, key string) (*domain.DietAnalysis, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, domain.ErrNotFound
+	}
+
+	analysis := entry.analysis
+	analysis.Items = slices.Clone(entry.analysis.Items)
+	return &analysis, nil
+}
+
+func (c *AnalysisCache) Set(ctx context.Context, key string, analysis domain.DietAnalysis, expiresAt time.Time) error {
+	analysis.Items = slices.Clone(analysis.Items)
+
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{analysis: analysis, expiresAt: expiresAt}
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *AnalysisCache) DeleteExpired(ctx context.Context) error {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+func newClient(apiKey string) *openai.Client {
+	// Retries are left to the caller so they are not multiplied by the
+	// client's own
+	client := openai.NewClient(option.WithAPIKey(apiKey), option.WithMaxRetries(0))
+	return &client
+}
+
+// jsonCompletion is a chat completion whose response must follow a strict
+// JSON schema
+type jsonCompletion struct {
+	Model     string
+	Messages  []openai.ChatCompletionMessageParamUnion
+	MaxTokens int64
+	// Name identifies the schema to the model
+	Name   string
+	Schema map[string]any
+}
+
+// completeJSON runs one chat completion and adds its token usage to usage.
+// It returns the model's refusal instead of the content when it refused.
+func completeJSON(ctx context.Context, client *openai.Client, c jsonCompletion, usage *domain.TokenUsage) (content, refusal string, err error) {
+	params := openai.ChatCompletionNewParams{
+		Messages:            c.Messages,
+		Model:               c.Model,
+		MaxCompletionTokens: openai.Int(c.MaxTokens),
+		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{
+				JSONSchema: openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   c.Name,
+					Schema: c.Schema,
+					Strict: openai.Bool(true),
+				},
+			},
+		},
+	}
+
+	response, err := client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		return "", "", fmt.Errorf("openai api request failed: %w", err)
+	}
+
+	usage.Model = response.Model
+	usage.PromptTokens += response.Usage.PromptTokens
+	usage.CompletionTokens += response.Usage.CompletionTokens
+	usage.CostUSD += estimateCost(response.Model, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+
+	if len(response.Choices) == 0 {
+		return "", "", fmt.Errorf("no response from openai")
+	}
+
+	message := response.Choices[0].Message
+	return message.Content, message.Refusal, nil
+}
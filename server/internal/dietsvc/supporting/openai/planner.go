@@ -0,0 +1,228 @@
+package openai
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// PlannerClient generates meal plans through text-only chat completions
+type PlannerClient struct {
+	client *openai.Client
+	model  string
+	prompt *planPrompt
+}
+
+var _ domain.MealPlanner = (*PlannerClient)(nil)
+
+// NewPlannerClient uses the model of cfg and its PlanPromptVersion
+func NewPlannerClient(cfg Config) (*PlannerClient, error) {
+	if cfg.Model == "" {
+		cfg.Model = openai.ChatModelGPT5Mini
+	}
+	if cfg.PlanPromptVersion == "" {
+		cfg.PlanPromptVersion = DefaultPlanPromptVersion
+	}
+
+	prompt, err := loadPlanPrompt(cfg.PlanPromptVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlannerClient{
+		client: newClient(cfg.APIKey),
+		model:  cfg.Model,
+		prompt: prompt,
+	}, nil
+}
+
+func (p *PlannerClient) Provenance() domain.Provenance {
+	return domain.Provenance{
+		Model:         p.model,
+		PromptVersion: p.prompt.version,
+	}
+}
+
+// PlanMeals generates the requested days, giving the model one chance to
+// repair a plan that misses a date, a meal, the targets or a restriction
+func (p *PlannerClient) PlanMeals(ctx context.Context, req domain.MealPlanRequest) (*domain.GeneratedPlan, error) {
+	data := planPromptData{
+		Dates:        make([]string, 0, len(req.Dates)),
+		Targets:      req.Targets,
+		Restrictions: make([]string, 0, len(req.Restrictions)),
+		RecentFoods:  req.RecentFoods,
+		Avoid:        req.Avoid,
+		Notes:        req.Notes,
+	}
+	for _, date := range req.Dates {
+		data.Dates = append(data.Dates, date.Format("Monday "+time.DateOnly))
+	}
+	for _, restriction := range req.Restrictions {
+		data.Restrictions = append(data.Restrictions, string(restriction))
+	}
+
+	prompt, err := render(p.prompt.plan, data)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.UserMessage(prompt),
+	}
+
+	var usage domain.TokenUsage
+	content, err := p.complete(ctx, messages, &usage)
+	if err != nil {
+		return nil, domain.WithUsage(err, usage)
+	}
+
+	plan, err := parsePlan(content, req)
+	if err == nil {
+		p.annotate(plan, usage)
+		return plan, nil
+	}
+
+	slog.Warn("invalid openai meal plan, retrying", "error", err)
+	messages = append(messages,
+		openai.AssistantMessage(content),
+		openai.UserMessage(fmt.Sprintf(
+			"Your previous plan was rejected: %s. Return a corrected plan for all the dates.", err,
+		)),
+	)
+
+	content, err = p.complete(ctx, messages, &usage)
+	if err != nil {
+		return nil, domain.WithUsage(err, usage)
+	}
+
+	plan, err = parsePlan(content, req)
+	if err != nil {
+		slog.Error("invalid openai meal plan after repair", "error", err)
+		return nil, domain.WithUsage(err, usage)
+	}
+	p.annotate(plan, usage)
+
+	return plan, nil
+}
+
+// annotate records what produced the plan and what it cost
+func (p *PlannerClient) annotate(plan *domain.GeneratedPlan, usage domain.TokenUsage) {
+	plan.Provenance = domain.Provenance{
+		Model:         usage.Model,
+		PromptVersion: p.prompt.version,
+	}
+	plan.Usage = usage
+}
+
+func (p *PlannerClient) complete(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion, usage *domain.TokenUsage) (string, error) {
+	content, refusal, err := completeJSON(ctx, p.client, jsonCompletion{
+		Model:    p.model,
+		Messages: messages,
+		// A week of meals is far longer than an analysis, and reasoning
+		// models spend part of the budget before answering
+		MaxTokens: 16000,
+		Name:      "meal_plan",
+		Schema:    p.prompt.schema,
+	}, usage)
+	if err != nil {
+		return "", err
+	}
+	if refusal != "" {
+		return "", domain.PlanFailed("model refused: " + refusal)
+	}
+
+	return content, nil
+}
+
+type planResult struct {
+	Days []planDayResult `json:"days"`
+}
+
+type planDayResult struct {
+	Date  string       `json:"date"`
+	Meals []mealResult `json:"meals"`
+}
+
+type mealResult struct {
+	MealType    string   `json:"meal_type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Ingredients []string `json:"ingredients"`
+	Allergens   []string `json:"allergens"`
+	Diet        string   `json:"diet"`
+	Calories    float64  `json:"calories"`
+	Protein     float64  `json:"protein"`
+	Fat         float64  `json:"fat"`
+	Carbs       float64  `json:"carbs"`
+}
+
+// parsePlan decodes a model response and validates it against the request.
+// Any failure is returned as a PlanFailed error with the reason.
+func parsePlan(content string, req domain.MealPlanRequest) (*domain.GeneratedPlan, error) {
+	var result planResult
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil, domain.PlanFailed("response is not valid JSON: " + err.Error())
+	}
+
+	plan := &domain.GeneratedPlan{
+		Days: make([]domain.MealPlanDay, 0, len(result.Days)),
+	}
+	for _, dayResult := range result.Days {
+		date, err := time.Parse(time.DateOnly, dayResult.Date)
+		if err != nil {
+			return nil, domain.PlanFailed(fmt.Sprintf("date %q is not formatted as YYYY-MM-DD", dayResult.Date))
+		}
+
+		day := domain.MealPlanDay{
+			Date:  date,
+			Meals: make([]domain.PlannedMeal, 0, len(dayResult.Meals)),
+		}
+		for _, meal := range dayResult.Meals {
+			// Unknown allergens and diets are dropped like in analyses
+			var allergens []domain.Allergen
+			for _, allergen := range meal.Allergens {
+				if allergen := domain.Allergen(allergen); allergen.IsValid() && !slices.Contains(allergens, allergen) {
+					allergens = append(allergens, allergen)
+				}
+			}
+			diet := domain.Diet(meal.Diet)
+			if !diet.IsValid() {
+				diet = ""
+			}
+
+			day.Meals = append(day.Meals, domain.PlannedMeal{
+				MealType:    domain.MealType(meal.MealType),
+				Name:        meal.Name,
+				Description: meal.Description,
+				Ingredients: meal.Ingredients,
+				Allergens:   allergens,
+				Diet:        diet,
+				Nutrients: domain.Nutrients{
+					Calories: meal.Calories,
+					Protein:  meal.Protein,
+					Fat:      meal.Fat,
+					Carbs:    meal.Carbs,
+				},
+			})
+		}
+		slices.SortStableFunc(day.Meals, func(a, b domain.PlannedMeal) int {
+			return cmp.Compare(slices.Index(domain.MealTypes, a.MealType), slices.Index(domain.MealTypes, b.MealType))
+		})
+		day.SumMeals()
+
+		plan.Days = append(plan.Days, day)
+	}
+
+	if err := plan.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,39 @@
+package openai
+
+import (
+	"strings"
+
+	"github.com/openai/openai-go/v3"
+)
+
+type modelPrice struct {
+	// Input and Output are list prices in USD per million tokens
+	Input  float64
+	Output float64
+}
+
+// modelPrices is keyed by model family; responses name a dated snapshot such
+// as gpt-5-mini-2025-08-07, which is matched by the longest prefix
+var modelPrices = map[string]modelPrice{
+	openai.ChatModelGPT5:       {Input: 1.25, Output: 10},
+	openai.ChatModelGPT5Mini:   {Input: 0.25, Output: 2},
+	openai.ChatModelGPT5Nano:   {Input: 0.05, Output: 0.40},
+	openai.ChatModelGPT4_1:     {Input: 2, Output: 8},
+	openai.ChatModelGPT4_1Mini: {Input: 0.40, Output: 1.60},
+	openai.ChatModelGPT4o:      {Input: 2.50, Output: 10},
+	openai.ChatModelGPT4oMini:  {Input: 0.15, Output: 0.60},
+}
+
+// estimateCost returns the list-price cost of a call in USD, or 0 for models
+// without a known price
+func estimateCost(model string, promptTokens, completionTokens int64) float64 {
+	var price modelPrice
+	matched := ""
+	for family, p := range modelPrices {
+		if strings.HasPrefix(model, family) && len(family) > len(matched) {
+			price, matched = p, family
+		}
+	}
+
+	return (float64(promptTokens)*price.Input + float64(completionTokens)*price.Output) / 1e6
+}
@@ -0,0 +1,166 @@
+package openai
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// DefaultPromptVersion is used when no prompt version is configured
+const DefaultPromptVersion = "v3"
+
+// Prompt templates live in prompts/<version>/ as image.tmpl and text.tmpl,
+// with schema.json, the strict JSON schema the response must follow. A
+// published version must not be edited: changing a prompt or its schema means
+// adding a new version, so analyses stay traceable to exactly what was sent.
+//
+//go:embed prompts
+var promptFS embed.FS
+
+// DefaultPlanPromptVersion is used when no meal plan prompt version is
+// configured
+const DefaultPlanPromptVersion = "v1"
+
+// Meal plan prompts live in planprompts/<version>/ as plan.tmpl and
+// schema.json. They are
+// versioned apart from the analysis prompts, so changing how meals are
+// planned does not invalidate cached analyses; the same no-edit rule applies.
+//
+//go:embed planprompts
+var planPromptFS embed.FS
+
+type promptSet struct {
+	version string
+	image   *template.Template
+	text    *template.Template
+	schema  map[string]any
+}
+
+type planPrompt struct {
+	version string
+	plan    *template.Template
+	schema  map[string]any
+}
+
+type imagePromptData struct {
+	Hints domain.AnalysisHints
+}
+
+type textPromptData struct {
+	Description string
+}
+
+type planPromptData struct {
+	// Dates are formatted like "Monday 2025-01-20"
+	Dates        []string
+	Targets      domain.Nutrients
+	Restrictions []string
+	RecentFoods  []string
+	Avoid        []string
+	Notes        string
+}
+
+// PromptVersions lists the embedded prompt versions
+func PromptVersions() []string {
+	return versions(promptFS, "prompts")
+}
+
+// PlanPromptVersions lists the embedded meal plan prompt versions
+func PlanPromptVersions() []string {
+	return versions(planPromptFS, "planprompts")
+}
+
+func versions(fsys fs.FS, dir string) []string {
+	entries, _ := fs.ReadDir(fsys, dir)
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+	slices.Sort(versions)
+
+	return versions
+}
+
+func loadPrompts(version string) (*promptSet, error) {
+	if !slices.Contains(PromptVersions(), version) {
+		return nil, fmt.Errorf("unknown prompt version %q, available: %s", version, strings.Join(PromptVersions(), ", "))
+	}
+
+	image, err := template.ParseFS(promptFS, "prompts/"+version+"/image.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image prompt %s: %w", version, err)
+	}
+
+	text, err := template.ParseFS(promptFS, "prompts/"+version+"/text.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text prompt %s: %w", version, err)
+	}
+
+	schema, err := loadSchema(promptFS, "prompts/"+version+"/schema.json")
+	if err != nil {
+		return nil, err
+	}
+
+	return &promptSet{version: version, image: image, text: text, schema: schema}, nil
+}
+
+func loadPlanPrompt(version string) (*planPrompt, error) {
+	if !slices.Contains(PlanPromptVersions(), version) {
+		return nil, fmt.Errorf("unknown meal plan prompt version %q, available: %s", version, strings.Join(PlanPromptVersions(), ", "))
+	}
+
+	plan, err := template.New("plan.tmpl").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		ParseFS(planPromptFS, "planprompts/"+version+"/plan.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse meal plan prompt %s: %w", version, err)
+	}
+
+	schema, err := loadSchema(planPromptFS, "planprompts/"+version+"/schema.json")
+	if err != nil {
+		return nil, err
+	}
+
+	return &planPrompt{version: version, plan: plan, schema: schema}, nil
+}
+
+func loadSchema(fsys fs.FS, path string) (map[string]any, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response schema %s: %w", path, err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse response schema %s: %w", path, err)
+	}
+
+	return schema, nil
+}
+
+func (p *promptSet) imagePrompt(hints domain.AnalysisHints) (string, error) {
+	return render(p.image, imagePromptData{Hints: hints})
+}
+
+func (p *promptSet) textPrompt(description string) (string, error) {
+	return render(p.text, textPromptData{Description: description})
+}
+
+func render(tmpl *template.Template, data any) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %s: %w", tmpl.Name(), err)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
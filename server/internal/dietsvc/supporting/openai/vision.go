@@ -4,38 +4,91 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
+	"slices"
 
 	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
 	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
 )
 
+type Config struct {
+	APIKey string
+	// Model defaults to gpt-5-mini
+	Model string
+	// PromptVersion selects the prompt templates; defaults to
+	// DefaultPromptVersion
+	PromptVersion string
+	// PlanPromptVersion selects the meal plan prompt; defaults to
+	// DefaultPlanPromptVersion
+	PlanPromptVersion string
+}
+
 type VisionClient struct {
-	client *openai.Client
+	client  *openai.Client
+	model   string
+	prompts *promptSet
 }
 
-func NewVisionClient(apiKey string) *VisionClient {
-	client := openai.NewClient(option.WithAPIKey(apiKey))
-	return &VisionClient{client: &client}
+var _ domain.FoodAnalyzer = (*VisionClient)(nil)
+
+func NewVisionClient(cfg Config) (*VisionClient, error) {
+	if cfg.Model == "" {
+		cfg.Model = openai.ChatModelGPT5Mini
+	}
+	if cfg.PromptVersion == "" {
+		cfg.PromptVersion = DefaultPromptVersion
+	}
+
+	prompts, err := loadPrompts(cfg.PromptVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VisionClient{
+		client:  newClient(cfg.APIKey),
+		model:   cfg.Model,
+		prompts: prompts,
+	}, nil
 }
 
-func (v *VisionClient) AnalyzeFood(ctx context.Context, imageData []byte, mimeType string) (*domain.DietAnalysis, error) {
-	base64Image := base64.StdEncoding.EncodeToString(imageData)
-	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Image)
+// Provenance names the configured model and prompt version. Analyses record
+// the exact model snapshot the provider reports instead.
+func (v *VisionClient) Provenance() domain.Provenance {
+	return domain.Provenance{
+		Model:         v.model,
+		PromptVersion: v.prompts.version,
+	}
+}
 
-	prompt := `Analyze this food image and provide nutritional estimates in JSON format.
-Return ONLY a JSON object with these exact fields:
-{
-  "food_name": "Brief description of the food items",
-  "calories": estimated total calories (number),
-  "protein": estimated protein in grams (number),
-  "fat": estimated fat in grams (number),
-  "carbs": estimated carbohydrates in grams (number)
+// IsRetryable reports whether err is a transient provider failure: a rate
+// limit, a server error, or a network error or attempt that timed out.
+// Everything else, including rejected analyses, is final.
+func IsRetryable(err error) bool {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
-Provide your best estimates based on typical portion sizes. Do not include any explanation, only return the JSON object.`
+func (v *VisionClient) AnalyzeFood(ctx context.Context, imageData []byte, mimeType string, hints domain.AnalysisHints) (*domain.DietAnalysis, error) {
+	prompt, err := v.prompts.imagePrompt(hints)
+	if err != nil {
+		return nil, err
+	}
+
+	base64Image := base64.StdEncoding.EncodeToString(imageData)
+	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Image)
 
 	messages := []openai.ChatCompletionMessageParamUnion{
 		openai.UserMessage([]openai.ChatCompletionContentPartUnionParam{
@@ -46,41 +99,172 @@ Provide your best estimates based on typical portion sizes. Do not include any e
 		}),
 	}
 
-	params := openai.ChatCompletionNewParams{
-		Messages:            messages,
-		Model:               openai.ChatModelGPT5Mini,
-		MaxCompletionTokens: openai.Int(5000),
+	return v.analyze(ctx, messages)
+}
+
+func (v *VisionClient) AnalyzeFoodText(ctx context.Context, description string) (*domain.DietAnalysis, error) {
+	prompt, err := v.prompts.textPrompt(description)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.UserMessage(prompt),
+	}
+
+	return v.analyze(ctx, messages)
+}
+
+// analyze runs the completion and parses the result, giving the model one
+// chance to repair invalid output. Errors carry the usage of the completions
+// that ran.
+func (v *VisionClient) analyze(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (*domain.DietAnalysis, error) {
+	var usage domain.TokenUsage
+	content, err := v.complete(ctx, messages, &usage)
+	if err != nil {
+		return nil, domain.WithUsage(err, usage)
+	}
+
+	analysis, err := parseAnalysis(content)
+	if err == nil {
+		v.annotate(analysis, usage)
+		return analysis, nil
 	}
 
-	response, err := v.client.Chat.Completions.New(ctx, params)
+	slog.Warn("invalid openai analysis, retrying", "content", content, "error", err)
+	messages = append(messages,
+		openai.AssistantMessage(content),
+		openai.UserMessage(fmt.Sprintf(
+			"Your previous response was rejected: %s. Re-examine the meal and return a corrected analysis.", err,
+		)),
+	)
+
+	content, err = v.complete(ctx, messages, &usage)
 	if err != nil {
-		return nil, fmt.Errorf("openai api request failed: %w", err)
+		return nil, domain.WithUsage(err, usage)
 	}
 
-	if len(response.Choices) == 0 {
-		return nil, fmt.Errorf("no response from openai")
+	analysis, err = parseAnalysis(content)
+	if err != nil {
+		slog.Error("invalid openai analysis after repair", "content", content, "error", err)
+		return nil, domain.WithUsage(err, usage)
 	}
+	v.annotate(analysis, usage)
 
-	content := response.Choices[0].Message.Content
+	return analysis, nil
+}
 
-	var result struct {
-		FoodName string  `json:"food_name"`
-		Calories float64 `json:"calories"`
-		Protein  float64 `json:"protein"`
-		Fat      float64 `json:"fat"`
-		Carbs    float64 `json:"carbs"`
+// annotate records what produced the analysis and what it cost
+func (v *VisionClient) annotate(analysis *domain.DietAnalysis, usage domain.TokenUsage) {
+	analysis.Provenance = domain.Provenance{
+		Model:         usage.Model,
+		PromptVersion: v.prompts.version,
+	}
+	analysis.Usage = usage
+}
+
+// complete runs one chat completion and adds its token usage to usage
+func (v *VisionClient) complete(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion, usage *domain.TokenUsage) (string, error) {
+	content, refusal, err := completeJSON(ctx, v.client, jsonCompletion{
+		Model:     v.model,
+		Messages:  messages,
+		MaxTokens: 5000,
+		Name:      "diet_analysis",
+		Schema:    v.prompts.schema,
+	}, usage)
+	if err != nil {
+		return "", err
+	}
+	if refusal != "" {
+		return "", domain.AnalysisFailed("model refused: " + refusal)
 	}
 
+	return content, nil
+}
+
+type analysisResult struct {
+	IsFood     bool         `json:"is_food"`
+	Confidence float64      `json:"confidence"`
+	FoodName   string       `json:"food_name"`
+	MealType   *string      `json:"meal_type"`
+	Items      []itemResult `json:"items"`
+}
+
+type itemResult struct {
+	Name         string   `json:"name"`
+	PortionGrams float64  `json:"portion_grams"`
+	Servings     float64  `json:"servings"`
+	Calories     float64  `json:"calories"`
+	Protein      float64  `json:"protein"`
+	Fat          float64  `json:"fat"`
+	Carbs        float64  `json:"carbs"`
+	Fiber        *float64 `json:"fiber"`
+	Sugar        *float64 `json:"sugar"`
+	SaturatedFat *float64 `json:"saturated_fat"`
+	SodiumMg     *float64 `json:"sodium_mg"`
+	Ingredients  []string `json:"ingredients"`
+	Allergens    []string `json:"allergens"`
+	Diet         string   `json:"diet"`
+}
+
+// parseAnalysis decodes and validates a model response. Any failure is
+// returned as an AnalysisFailed error with the reason.
+func parseAnalysis(content string) (*domain.DietAnalysis, error) {
+	var result analysisResult
 	if err := json.Unmarshal([]byte(content), &result); err != nil {
-		slog.Error("failed to parse openai response", "content", content, "error", err)
-		return nil, fmt.Errorf("failed to parse openai response: %w", err)
+		return nil, domain.AnalysisFailed("response is not valid JSON: " + err.Error())
 	}
 
-	return &domain.DietAnalysis{
-		FoodName: result.FoodName,
-		Calories: result.Calories,
-		Protein:  result.Protein,
-		Fat:      result.Fat,
-		Carbs:    result.Carbs,
-	}, nil
+	analysis := &domain.DietAnalysis{
+		FoodName:   result.FoodName,
+		Items:      make([]domain.FoodItem, 0, len(result.Items)),
+		IsFood:     result.IsFood,
+		Confidence: result.Confidence,
+	}
+	for _, item := range result.Items {
+		// Unknown allergens and diets are dropped rather than failing the
+		// analysis
+		var allergens []domain.Allergen
+		for _, allergen := range item.Allergens {
+			if allergen := domain.Allergen(allergen); allergen.IsValid() && !slices.Contains(allergens, allergen) {
+				allergens = append(allergens, allergen)
+			}
+		}
+		diet := domain.Diet(item.Diet)
+		if !diet.IsValid() {
+			diet = ""
+		}
+
+		analysis.Items = append(analysis.Items, domain.FoodItem{
+			Name:         item.Name,
+			PortionGrams: item.PortionGrams,
+			Servings:     item.Servings,
+			Calories:     item.Calories,
+			Protein:      item.Protein,
+			Fat:          item.Fat,
+			Carbs:        item.Carbs,
+			Ingredients:  item.Ingredients,
+			Allergens:    allergens,
+			Diet:         diet,
+			Micronutrients: domain.Micronutrients{
+				Fiber:        item.Fiber,
+				Sugar:        item.Sugar,
+				SaturatedFat: item.SaturatedFat,
+				SodiumMg:     item.SodiumMg,
+			},
+		})
+	}
+	// An unknown meal type is dropped rather than failing the analysis
+	if result.MealType != nil {
+		if mealType := domain.MealType(*result.MealType); mealType.IsValid() {
+			analysis.MealType = mealType
+		}
+	}
+	analysis.SumItems()
+
+	if err := analysis.Validate(); err != nil {
+		return nil, err
+	}
+
+	return analysis, nil
 }
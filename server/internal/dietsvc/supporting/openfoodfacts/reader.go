@@ -0,0 +1,124 @@
+package openfoodfacts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// Source is the catalog name recorded on imported products
+const Source = "openfoodfacts"
+
+// maxLineSize bounds a single product row; some rows carry long ingredient
+// lists
+const maxLineSize = 16 * 1024 * 1024
+
+var requiredColumns = []string{
+	"code", "product_name", "energy-kcal_100g", "proteins_100g", "fat_100g", "carbohydrates_100g",
+}
+
+// Read streams products from the Open Food Facts CSV export, which is tab
+// separated and unquoted, calling fn for each product. Rows without a name,
+// barcode, calories or macros are skipped and counted. Sodium is converted from
+// grams to milligrams.
+func Read(r io.Reader, fn func(domain.Product) error) (skipped int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return 0, fmt.Errorf("failed to read header: %w", err)
+		}
+		return 0, fmt.Errorf("file is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range strings.Split(scanner.Text(), "\t") {
+		columns[name] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return 0, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	for scanner.Scan() {
+		product, ok := parseRow(strings.Split(scanner.Text(), "\t"), columns)
+		if !ok {
+			skipped++
+			continue
+		}
+		if err := fn(product); err != nil {
+			return skipped, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return skipped, err
+	}
+
+	return skipped, nil
+}
+
+func parseRow(fields []string, columns map[string]int) (domain.Product, bool) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+	number := func(name string) *float64 {
+		v, err := strconv.ParseFloat(field(name), 64)
+		if err != nil {
+			return nil
+		}
+		return &v
+	}
+
+	product := domain.Product{
+		Barcode: field("code"),
+		Name:    field("product_name"),
+		Source:  Source,
+	}
+	if product.Barcode == "" || product.Name == "" {
+		return product, false
+	}
+
+	// Brands are a comma-separated list; the first is the product's own
+	brand, _, _ := strings.Cut(field("brands"), ",")
+	product.Brand = strings.TrimSpace(brand)
+
+	for _, n := range []struct {
+		column string
+		value  *float64
+	}{
+		{"energy-kcal_100g", &product.Calories},
+		{"proteins_100g", &product.Protein},
+		{"fat_100g", &product.Fat},
+		{"carbohydrates_100g", &product.Carbs},
+	} {
+		v := number(n.column)
+		if v == nil {
+			return product, false
+		}
+		*n.value = *v
+	}
+
+	if serving := number("serving_quantity"); serving != nil && *serving > 0 {
+		product.ServingGrams = serving
+	}
+
+	product.Fiber = number("fiber_100g")
+	product.Sugar = number("sugars_100g")
+	product.SaturatedFat = number("saturated-fat_100g")
+	if sodium := number("sodium_100g"); sodium != nil {
+		mg := *sodium * 1000
+		product.SodiumMg = &mg
+	}
+
+	return product, true
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type analysisRepository struct {
+	queries *Queries
+}
+
+func NewAnalysisRepository(db *sql.DB) domain.AnalysisRepository {
+	return &analysisRepository{
+		queries: New(db),
+	}
+}
+
+// analysisItem is the stored form of an item estimate
+type analysisItem struct {
+	Name     string  `json:"name"`
+	Calories float64 `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Fat      float64 `json:"fat"`
+	Carbs    float64 `json:"carbs"`
+}
+
+func (r *analysisRepository) Create(ctx context.Context, analysis domain.StoredAnalysis) (*domain.StoredAnalysis, error) {
+	stored := make([]analysisItem, 0, len(analysis.Items))
+	for _, item := range analysis.Items {
+		stored = append(stored, analysisItem{
+			Name:     item.Name,
+			Calories: item.Calories,
+			Protein:  item.Protein,
+			Fat:      item.Fat,
+			Carbs:    item.Carbs,
+		})
+	}
+	items, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal analysis items: %w", err)
+	}
+
+	dbAnalysis, err := r.queries.CreateAnalysis(ctx, CreateAnalysisParams{
+		UserID:        analysis.UserID,
+		Kind:          string(analysis.Kind),
+		FoodName:      analysis.FoodName,
+		Calories:      analysis.Original.Calories,
+		Protein:       analysis.Original.Protein,
+		Fat:           analysis.Original.Fat,
+		Carbs:         analysis.Original.Carbs,
+		Confidence:    analysis.Confidence,
+		Model:         analysis.Model,
+		PromptVersion: analysis.PromptVersion,
+		Items:         items,
+		Cached:        analysis.Cached,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainStoredAnalysis(dbAnalysis)
+}
+
+func (r *analysisRepository) Get(ctx context.Context, userID, id uuid.UUID) (*domain.StoredAnalysis, error) {
+	dbAnalysis, err := r.queries.GetAnalysis(ctx, GetAnalysisParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainStoredAnalysis(dbAnalysis)
+}
+
+func (r *analysisRepository) Correct(ctx context.Context, userID, id uuid.UUID, correction domain.Correction) (*domain.StoredAnalysis, error) {
+	dbAnalysis, err := r.queries.CorrectAnalysis(ctx, CorrectAnalysisParams{
+		CorrectedCalories: toNullFloat64(correction.Calories),
+		CorrectedProtein:  toNullFloat64(correction.Protein),
+		CorrectedFat:      toNullFloat64(correction.Fat),
+		CorrectedCarbs:    toNullFloat64(correction.Carbs),
+		ID:                id,
+		UserID:            userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainStoredAnalysis(dbAnalysis)
+}
+
+func (r *analysisRepository) AccuracyReport(ctx context.Context, query domain.AccuracyQuery) (*domain.AccuracyReport, error) {
+	versionRows, err := r.queries.AccuracyByPromptVersion(ctx, AccuracyByPromptVersionParams{
+		CreatedFrom: query.From,
+		CreatedTo:   query.To,
+		MinSamples:  int64(query.MinSamples),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	foodRows, err := r.queries.AccuracyByFood(ctx, AccuracyByFoodParams{
+		CreatedFrom: query.From,
+		CreatedTo:   query.To,
+		MinSamples:  int64(query.MinSamples),
+		RowLimit:    int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.AccuracyReport{
+		PromptVersions: make([]domain.AccuracyGroup, 0, len(versionRows)),
+		Foods:          make([]domain.AccuracyGroup, 0, len(foodRows)),
+	}
+	for _, row := range versionRows {
+		report.PromptVersions = append(report.PromptVersions, domain.AccuracyGroup{
+			PromptVersion: row.PromptVersion,
+			Samples:       int(row.Samples),
+			MAE:           toNutrientErrors(row.MaeCalories, row.MaeProtein, row.MaeFat, row.MaeCarbs),
+		})
+	}
+	for _, row := range foodRows {
+		report.Foods = append(report.Foods, domain.AccuracyGroup{
+			FoodName:      row.FoodName,
+			PromptVersion: row.PromptVersion,
+			Samples:       int(row.Samples),
+			MAE:           toNutrientErrors(row.MaeCalories, row.MaeProtein, row.MaeFat, row.MaeCarbs),
+		})
+	}
+
+	return report, nil
+}
+
+func toNutrientErrors(calories, protein, fat, carbs sql.NullFloat64) domain.NutrientErrors {
+	return domain.NutrientErrors{
+		Calories: fromNullFloat64(calories),
+		Protein:  fromNullFloat64(protein),
+		Fat:      fromNullFloat64(fat),
+		Carbs:    fromNullFloat64(carbs),
+	}
+}
+
+func toDomainStoredAnalysis(dbAnalysis Analysis) (*domain.StoredAnalysis, error) {
+	analysis := &domain.StoredAnalysis{
+		ID:       dbAnalysis.ID,
+		UserID:   dbAnalysis.UserID,
+		Kind:     domain.JobKind(dbAnalysis.Kind),
+		FoodName: dbAnalysis.FoodName,
+		Original: domain.Nutrients{
+			Calories: dbAnalysis.Calories,
+			Protein:  dbAnalysis.Protein,
+			Fat:      dbAnalysis.Fat,
+			Carbs:    dbAnalysis.Carbs,
+		},
+		Confidence:    dbAnalysis.Confidence,
+		Model:         dbAnalysis.Model,
+		PromptVersion: dbAnalysis.PromptVersion,
+		Cached:        dbAnalysis.Cached,
+		CreatedAt:     dbAnalysis.CreatedAt,
+	}
+
+	var stored []analysisItem
+	if err := json.Unmarshal(dbAnalysis.Items, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal analysis items: %w", err)
+	}
+	analysis.Items = make([]domain.ItemEstimate, 0, len(stored))
+	for _, item := range stored {
+		analysis.Items = append(analysis.Items, domain.ItemEstimate{
+			Name: item.Name,
+			Nutrients: domain.Nutrients{
+				Calories: item.Calories,
+				Protein:  item.Protein,
+				Fat:      item.Fat,
+				Carbs:    item.Carbs,
+			},
+		})
+	}
+
+	if dbAnalysis.CorrectedAt.Valid {
+		analysis.Correction = &domain.Correction{
+			Calories:    fromNullFloat64(dbAnalysis.CorrectedCalories),
+			Protein:     fromNullFloat64(dbAnalysis.CorrectedProtein),
+			Fat:         fromNullFloat64(dbAnalysis.CorrectedFat),
+			Carbs:       fromNullFloat64(dbAnalysis.CorrectedCarbs),
+			CorrectedAt: dbAnalysis.CorrectedAt.Time,
+		}
+	}
+
+	return analysis, nil
+}
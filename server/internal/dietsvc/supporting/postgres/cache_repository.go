@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type cacheRepository struct {
+	queries *Queries
+}
+
+func NewAnalysisCache(db *sql.DB) domain.AnalysisCache {
+	return &cacheRepository{
+		queries: New(db),
+	}
+}
+
+func (r *cacheRepository) Get(ctx context.Context, key string) (*domain.DietAnalysis, error) {
+	dbEntry, err := r.queries.GetCachedAnalysis(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	var analysis domain.DietAnalysis
+	if err := json.Unmarshal(dbEntry.Analysis, &analysis); err != nil {
+		return nil, err
+	}
+
+	return &analysis, nil
+}
+
+func (r *cacheRepository) Set(ctx context.Context, key string, analysis domain.DietAnalysis, expiresAt time.Time) error {
+	data, err := json.Marshal(analysis)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.UpsertCachedAnalysis(ctx, UpsertCachedAnalysisParams{
+		CacheKey:  key,
+		Analysis:  data,
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (r *cacheRepository) DeleteExpired(ctx context.Context) error {
+	return r.queries.DeleteExpiredCachedAnalyses(ctx)
+}
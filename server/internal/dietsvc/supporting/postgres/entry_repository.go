@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type entryRepository struct {
+	queries *Queries
+}
+
+func NewEntryRepository(db *sql.DB) domain.EntryRepository {
+	return &entryRepository{
+		queries: New(db),
+	}
+}
+
+func (r *entryRepository) Create(ctx context.Context, entry domain.DietEntry) (*domain.DietEntry, error) {
+	dbEntry, err := r.queries.CreateDietEntry(ctx, CreateDietEntryParams{
+		UserID:        entry.UserID,
+		Name:          entry.Name,
+		Description:   toNullString(entry.Description),
+		Calories:      entry.Calories,
+		Protein:       entry.Protein,
+		Fat:           entry.Fat,
+		Carbs:         entry.Carbs,
+		EatenAt:       entry.EatenAt,
+		ImageRef:      toNullString(entry.ImageRef),
+		Source:        string(entry.Source),
+		Confidence:    toNullFloat64(entry.Confidence),
+		Fiber:         toNullFloat64(entry.Fiber),
+		Sugar:         toNullFloat64(entry.Sugar),
+		SaturatedFat:  toNullFloat64(entry.SaturatedFat),
+		SodiumMg:      toNullFloat64(entry.SodiumMg),
+		MealType:      toNullString(string(entry.MealType)),
+		AnalysisID:    toNullUUID(entry.AnalysisID),
+		Model:         toNullString(entry.Model),
+		PromptVersion: toNullString(entry.PromptVersion),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainEntry(dbEntry), nil
+}
+
+func (r *entryRepository) Get(ctx context.Context, userID, id uuid.UUID) (*domain.DietEntry, error) {
+	dbEntry, err := r.queries.GetDietEntry(ctx, GetDietEntryParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainEntry(dbEntry), nil
+}
+
+func (r *entryRepository) List(ctx context.Context, userID uuid.UUID, filter domain.EntryFilter) ([]domain.DietEntry, error) {
+	params := ListDietEntriesParams{
+		UserID:   userID,
+		RowLimit: int32(filter.Limit),
+	}
+	if filter.From != nil {
+		params.FromTime = sql.NullTime{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		params.ToTime = sql.NullTime{Time: *filter.To, Valid: true}
+	}
+	if filter.Cursor != nil {
+		params.CursorEatenAt = sql.NullTime{Time: filter.Cursor.EatenAt, Valid: true}
+		params.CursorID = uuid.NullUUID{UUID: filter.Cursor.ID, Valid: true}
+	}
+
+	dbEntries, err := r.queries.ListDietEntries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.DietEntry, 0, len(dbEntries))
+	for _, dbEntry := range dbEntries {
+		entries = append(entries, *toDomainEntry(dbEntry))
+	}
+
+	return entries, nil
+}
+
+func (r *entryRepository) Update(ctx context.Context, entry domain.DietEntry) (*domain.DietEntry, error) {
+	dbEntry, err := r.queries.UpdateDietEntry(ctx, UpdateDietEntryParams{
+		Name:          entry.Name,
+		Description:   toNullString(entry.Description),
+		Calories:      entry.Calories,
+		Protein:       entry.Protein,
+		Fat:           entry.Fat,
+		Carbs:         entry.Carbs,
+		EatenAt:       entry.EatenAt,
+		ImageRef:      toNullString(entry.ImageRef),
+		Source:        string(entry.Source),
+		Confidence:    toNullFloat64(entry.Confidence),
+		Fiber:         toNullFloat64(entry.Fiber),
+		Sugar:         toNullFloat64(entry.Sugar),
+		SaturatedFat:  toNullFloat64(entry.SaturatedFat),
+		SodiumMg:      toNullFloat64(entry.SodiumMg),
+		MealType:      toNullString(string(entry.MealType)),
+		AnalysisID:    toNullUUID(entry.AnalysisID),
+		Model:         toNullString(entry.Model),
+		PromptVersion: toNullString(entry.PromptVersion),
+		ID:            entry.ID,
+		UserID:        entry.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainEntry(dbEntry), nil
+}
+
+func (r *entryRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	rows, err := r.queries.DeleteDietEntry(ctx, DeleteDietEntryParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *entryRepository) Summarize(ctx context.Context, query domain.SummaryQuery) ([]domain.PeriodSummary, error) {
+	rows, err := r.queries.SummarizeDietEntries(ctx, SummarizeDietEntriesParams{
+		Granularity: string(query.Granularity),
+		FromTime:    query.From,
+		TimeZone:    query.TimeZone,
+		ToTime:      query.To,
+		UserID:      query.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]domain.PeriodSummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, domain.PeriodSummary{
+			Start:      row.PeriodStart,
+			EntryCount: int(row.EntryCount),
+			DaysLogged: int(row.DaysLogged),
+			Totals: domain.Nutrients{
+				Calories: row.Calories,
+				Protein:  row.Protein,
+				Fat:      row.Fat,
+				Carbs:    row.Carbs,
+			},
+			DailyAverages: domain.Nutrients{
+				Calories: row.AvgDailyCalories,
+				Protein:  row.AvgDailyProtein,
+				Fat:      row.AvgDailyFat,
+				Carbs:    row.AvgDailyCarbs,
+			},
+		})
+	}
+
+	return summaries, nil
+}
+
+func (r *entryRepository) ListRecent(ctx context.Context, userID uuid.UUID, since time.Time) ([]domain.RecentFood, error) {
+	rows, err := r.queries.ListRecentFoods(ctx, ListRecentFoodsParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recents := make([]domain.RecentFood, 0, len(rows))
+	for _, row := range rows {
+		entry := toDomainEntry(DietEntry{
+			ID:            row.ID,
+			UserID:        row.UserID,
+			Name:          row.Name,
+			Description:   row.Description,
+			Calories:      row.Calories,
+			Protein:       row.Protein,
+			Fat:           row.Fat,
+			Carbs:         row.Carbs,
+			EatenAt:       row.EatenAt,
+			ImageRef:      row.ImageRef,
+			Source:        row.Source,
+			Confidence:    row.Confidence,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+			Fiber:         row.Fiber,
+			Sugar:         row.Sugar,
+			SaturatedFat:  row.SaturatedFat,
+			SodiumMg:      row.SodiumMg,
+			MealType:      row.MealType,
+			AnalysisID:    row.AnalysisID,
+			Model:         row.Model,
+			PromptVersion: row.PromptVersion,
+		})
+		recents = append(recents, domain.RecentFood{
+			Entry:    *entry,
+			LogCount: int(row.LogCount),
+		})
+	}
+
+	return recents, nil
+}
+
+func toDomainEntry(dbEntry DietEntry) *domain.DietEntry {
+	entry := &domain.DietEntry{
+		ID:        dbEntry.ID,
+		UserID:    dbEntry.UserID,
+		Name:      dbEntry.Name,
+		Calories:  dbEntry.Calories,
+		Protein:   dbEntry.Protein,
+		Fat:       dbEntry.Fat,
+		Carbs:     dbEntry.Carbs,
+		EatenAt:   dbEntry.EatenAt,
+		Source:    domain.EntrySource(dbEntry.Source),
+		CreatedAt: dbEntry.CreatedAt,
+		UpdatedAt: dbEntry.UpdatedAt,
+	}
+
+	if dbEntry.Description.Valid {
+		entry.Description = dbEntry.Description.String
+	}
+
+	if dbEntry.ImageRef.Valid {
+		entry.ImageRef = dbEntry.ImageRef.String
+	}
+
+	if dbEntry.MealType.Valid {
+		entry.MealType = domain.MealType(dbEntry.MealType.String)
+	}
+
+	if dbEntry.AnalysisID.Valid {
+		entry.AnalysisID = &dbEntry.AnalysisID.UUID
+	}
+	entry.Model = dbEntry.Model.String
+	entry.PromptVersion = dbEntry.PromptVersion.String
+
+	entry.Confidence = fromNullFloat64(dbEntry.Confidence)
+	entry.Fiber = fromNullFloat64(dbEntry.Fiber)
+	entry.Sugar = fromNullFloat64(dbEntry.Sugar)
+	entry.SaturatedFat = fromNullFloat64(dbEntry.SaturatedFat)
+	entry.SodiumMg = fromNullFloat64(dbEntry.SodiumMg)
+
+	return entry
+}
+
+func toNullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func toNullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+func fromNullFloat64(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type favoriteRepository struct {
+	queries *Queries
+}
+
+func NewFavoriteRepository(db *sql.DB) domain.FavoriteRepository {
+	return &favoriteRepository{
+		queries: New(db),
+	}
+}
+
+func (r *favoriteRepository) Upsert(ctx context.Context, favorite domain.Favorite) (*domain.Favorite, error) {
+	dbFavorite, err := r.queries.UpsertDietFavorite(ctx, UpsertDietFavoriteParams{
+		UserID:       favorite.UserID,
+		Name:         favorite.Name,
+		Description:  toNullString(favorite.Description),
+		Calories:     favorite.Calories,
+		Protein:      favorite.Protein,
+		Fat:          favorite.Fat,
+		Carbs:        favorite.Carbs,
+		Fiber:        toNullFloat64(favorite.Fiber),
+		Sugar:        toNullFloat64(favorite.Sugar),
+		SaturatedFat: toNullFloat64(favorite.SaturatedFat),
+		SodiumMg:     toNullFloat64(favorite.SodiumMg),
+		Source:       string(favorite.Source),
+		Confidence:   toNullFloat64(favorite.Confidence),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainFavorite(dbFavorite), nil
+}
+
+func (r *favoriteRepository) Get(ctx context.Context, userID, id uuid.UUID) (*domain.Favorite, error) {
+	dbFavorite, err := r.queries.GetDietFavorite(ctx, GetDietFavoriteParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainFavorite(dbFavorite), nil
+}
+
+func (r *favoriteRepository) List(ctx context.Context, userID uuid.UUID) ([]domain.Favorite, error) {
+	dbFavorites, err := r.queries.ListDietFavorites(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites := make([]domain.Favorite, 0, len(dbFavorites))
+	for _, dbFavorite := range dbFavorites {
+		favorites = append(favorites, *toDomainFavorite(dbFavorite))
+	}
+
+	return favorites, nil
+}
+
+func (r *favoriteRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	rows, err := r.queries.DeleteDietFavorite(ctx, DeleteDietFavoriteParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func toDomainFavorite(dbFavorite DietFavorite) *domain.Favorite {
+	return &domain.Favorite{
+		ID:          dbFavorite.ID,
+		UserID:      dbFavorite.UserID,
+		Name:        dbFavorite.Name,
+		Description: dbFavorite.Description.String,
+		Nutrients: domain.Nutrients{
+			Calories: dbFavorite.Calories,
+			Protein:  dbFavorite.Protein,
+			Fat:      dbFavorite.Fat,
+			Carbs:    dbFavorite.Carbs,
+		},
+		Micronutrients: domain.Micronutrients{
+			Fiber:        fromNullFloat64(dbFavorite.Fiber),
+			Sugar:        fromNullFloat64(dbFavorite.Sugar),
+			SaturatedFat: fromNullFloat64(dbFavorite.SaturatedFat),
+			SodiumMg:     fromNullFloat64(dbFavorite.SodiumMg),
+		},
+		Source:     domain.EntrySource(dbFavorite.Source),
+		Confidence: fromNullFloat64(dbFavorite.Confidence),
+		CreatedAt:  dbFavorite.CreatedAt,
+		UpdatedAt:  dbFavorite.UpdatedAt,
+	}
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// minFoodMatchScore is the lowest trigram word similarity returned by Search.
+// It is low enough to tolerate typos such as "chiken" for "chicken".
+const minFoodMatchScore = 0.3
+
+type foodRepository struct {
+	db      *sql.DB
+	queries *Queries
+}
+
+func NewFoodRepository(db *sql.DB) domain.FoodRepository {
+	return &foodRepository{
+		db:      db,
+		queries: New(db),
+	}
+}
+
+type serving struct {
+	Label string  `json:"label"`
+	Grams float64 `json:"grams"`
+}
+
+func (r *foodRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Food, error) {
+	dbFood, err := r.queries.GetFood(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainFood(dbFood)
+}
+
+// Search matches names with the <% operator so the trigram index is used. Its
+// threshold is a session setting, so it is set for a transaction of its own.
+func (r *foodRepository) Search(ctx context.Context, query string, limit int) ([]domain.Food, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	queries := r.queries.WithTx(tx)
+	if err := queries.SetWordSimilarityThreshold(ctx, minFoodMatchScore); err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.SearchFoods(ctx, SearchFoodsParams{
+		Query:    query,
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	foods := make([]domain.Food, 0, len(rows))
+	for _, row := range rows {
+		food, err := toDomainFood(Food{
+			ID:           row.ID,
+			Source:       row.Source,
+			SourceID:     row.SourceID,
+			Name:         row.Name,
+			Category:     row.Category,
+			Calories:     row.Calories,
+			Protein:      row.Protein,
+			Fat:          row.Fat,
+			Carbs:        row.Carbs,
+			Fiber:        row.Fiber,
+			Sugar:        row.Sugar,
+			SaturatedFat: row.SaturatedFat,
+			SodiumMg:     row.SodiumMg,
+			Servings:     row.Servings,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		foods = append(foods, *food)
+	}
+
+	return foods, nil
+}
+
+func (r *foodRepository) Upsert(ctx context.Context, food domain.Food) error {
+	servings := make([]serving, 0, len(food.Servings))
+	for _, s := range food.Servings {
+		servings = append(servings, serving{Label: s.Label, Grams: s.Grams})
+	}
+	servingsJSON, err := json.Marshal(servings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal servings: %w", err)
+	}
+
+	return r.queries.UpsertFood(ctx, UpsertFoodParams{
+		Source:       food.Source,
+		SourceID:     food.SourceID,
+		Name:         food.Name,
+		Category:     toNullString(food.Category),
+		Calories:     food.Calories,
+		Protein:      food.Protein,
+		Fat:          food.Fat,
+		Carbs:        food.Carbs,
+		Fiber:        toNullFloat64(food.Fiber),
+		Sugar:        toNullFloat64(food.Sugar),
+		SaturatedFat: toNullFloat64(food.SaturatedFat),
+		SodiumMg:     toNullFloat64(food.SodiumMg),
+		Servings:     servingsJSON,
+	})
+}
+
+func toDomainFood(dbFood Food) (*domain.Food, error) {
+	var servings []serving
+	if err := json.Unmarshal(dbFood.Servings, &servings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal servings: %w", err)
+	}
+
+	food := &domain.Food{
+		ID:       dbFood.ID,
+		Source:   dbFood.Source,
+		SourceID: dbFood.SourceID,
+		Name:     dbFood.Name,
+		Category: dbFood.Category.String,
+		Nutrients: domain.Nutrients{
+			Calories: dbFood.Calories,
+			Protein:  dbFood.Protein,
+			Fat:      dbFood.Fat,
+			Carbs:    dbFood.Carbs,
+		},
+		Micronutrients: domain.Micronutrients{
+			Fiber:        fromNullFloat64(dbFood.Fiber),
+			Sugar:        fromNullFloat64(dbFood.Sugar),
+			SaturatedFat: fromNullFloat64(dbFood.SaturatedFat),
+			SodiumMg:     fromNullFloat64(dbFood.SodiumMg),
+		},
+		Servings: make([]domain.Serving, 0, len(servings)),
+	}
+	for _, s := range servings {
+		food.Servings = append(food.Servings, domain.Serving{Label: s.Label, Grams: s.Grams})
+	}
+
+	return food, nil
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type jobRepository struct {
+	queries *Queries
+}
+
+func NewJobRepository(db *sql.DB) domain.JobRepository {
+	return &jobRepository{
+		queries: New(db),
+	}
+}
+
+func (r *jobRepository) Create(ctx context.Context, job domain.AnalysisJob) (*domain.AnalysisJob, error) {
+	hints, err := json.Marshal(job.Hints)
+	if err != nil {
+		return nil, err
+	}
+
+	dbJob, err := r.queries.CreateAnalysisJob(ctx, CreateAnalysisJobParams{
+		UserID:      job.UserID,
+		Kind:        string(job.Kind),
+		Image:       job.Image,
+		Description: toNullString(job.Description),
+		Hints:       hints,
+		BypassCache: job.BypassCache,
+		CallbackUrl: toNullString(job.CallbackURL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainJob(dbJob)
+}
+
+func (r *jobRepository) Get(ctx context.Context, userID, id uuid.UUID) (*domain.AnalysisJob, error) {
+	dbJob, err := r.queries.GetAnalysisJob(ctx, GetAnalysisJobParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainJob(dbJob)
+}
+
+func (r *jobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.AnalysisJob, error) {
+	dbJob, err := r.queries.ClaimAnalysisJob(ctx, staleBefore)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainJob(dbJob)
+}
+
+func (r *jobRepository) Complete(ctx context.Context, job domain.AnalysisJob) (*domain.AnalysisJob, error) {
+	// A nil result marshals to JSON null, the column's empty value
+	result, err := json.Marshal(job.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	params := CompleteAnalysisJobParams{
+		Status:   string(job.Status),
+		Result:   result,
+		Cached:   job.Cached,
+		ID:       job.ID,
+		Attempts: int32(job.Attempts),
+	}
+	if job.Error != nil {
+		params.ErrorCode = toNullString(job.Error.Code)
+		params.ErrorMessage = toNullString(job.Error.Message)
+	}
+
+	dbJob, err := r.queries.CompleteAnalysisJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainJob(dbJob)
+}
+
+func (r *jobRepository) Release(ctx context.Context, job domain.AnalysisJob, availableAt time.Time) error {
+	return r.queries.ReleaseAnalysisJob(ctx, ReleaseAnalysisJobParams{
+		AvailableAt: availableAt,
+		ID:          job.ID,
+		Attempts:    int32(job.Attempts),
+	})
+}
+
+func (r *jobRepository) DeleteCompletedBefore(ctx context.Context, before time.Time) error {
+	return r.queries.DeleteCompletedAnalysisJobs(ctx, before)
+}
+
+func toDomainJob(dbJob AnalysisJob) (*domain.AnalysisJob, error) {
+	job := &domain.AnalysisJob{
+		ID:          dbJob.ID,
+		UserID:      dbJob.UserID,
+		Kind:        domain.JobKind(dbJob.Kind),
+		Status:      domain.JobStatus(dbJob.Status),
+		Image:       dbJob.Image,
+		Description: dbJob.Description.String,
+		BypassCache: dbJob.BypassCache,
+		CallbackURL: dbJob.CallbackUrl.String,
+		Cached:      dbJob.Cached,
+		Attempts:    int(dbJob.Attempts),
+		CreatedAt:   dbJob.CreatedAt,
+	}
+
+	if err := json.Unmarshal(dbJob.Hints, &job.Hints); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dbJob.Result, &job.Result); err != nil {
+		return nil, err
+	}
+
+	if dbJob.ErrorCode.Valid {
+		job.Error = &domain.JobError{
+			Code:    dbJob.ErrorCode.String,
+			Message: dbJob.ErrorMessage.String,
+		}
+	}
+
+	if dbJob.StartedAt.Valid {
+		job.StartedAt = &dbJob.StartedAt.Time
+	}
+	if dbJob.CompletedAt.Valid {
+		job.CompletedAt = &dbJob.CompletedAt.Time
+	}
+
+	return job, nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type mealPlanRepository struct {
+	queries *Queries
+}
+
+func NewMealPlanRepository(db *sql.DB) domain.MealPlanRepository {
+	return &mealPlanRepository{
+		queries: New(db),
+	}
+}
+
+// mealPlanDay is the stored form of a planned day
+type mealPlanDay struct {
+	Date          string        `json:"date"`
+	Meals         []plannedMeal `json:"meals"`
+	Calories      float64       `json:"calories"`
+	Protein       float64       `json:"protein"`
+	Fat           float64       `json:"fat"`
+	Carbs         float64       `json:"carbs"`
+	GeneratedAt   time.Time     `json:"generated_at"`
+	Model         string        `json:"model"`
+	PromptVersion string        `json:"prompt_version"`
+}
+
+type plannedMeal struct {
+	MealType    string   `json:"meal_type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Ingredients []string `json:"ingredients"`
+	Allergens   []string `json:"allergens"`
+	Diet        string   `json:"diet,omitempty"`
+	Calories    float64  `json:"calories"`
+	Protein     float64  `json:"protein"`
+	Fat         float64  `json:"fat"`
+	Carbs       float64  `json:"carbs"`
+}
+
+func (r *mealPlanRepository) Create(ctx context.Context, plan domain.MealPlan) (*domain.MealPlan, error) {
+	restrictions, err := json.Marshal(plan.Restrictions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal meal plan restrictions: %w", err)
+	}
+
+	days, err := marshalPlanDays(plan.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPlan, err := r.queries.CreateMealPlan(ctx, CreateMealPlanParams{
+		UserID:         plan.UserID,
+		StartDate:      plan.StartDate,
+		TargetCalories: plan.Targets.Calories,
+		TargetProtein:  plan.Targets.Protein,
+		TargetFat:      plan.Targets.Fat,
+		TargetCarbs:    plan.Targets.Carbs,
+		Restrictions:   restrictions,
+		Notes:          toNullString(plan.Notes),
+		Days:           days,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainMealPlan(dbPlan)
+}
+
+func (r *mealPlanRepository) Get(ctx context.Context, userID, id uuid.UUID) (*domain.MealPlan, error) {
+	dbPlan, err := r.queries.GetMealPlan(ctx, GetMealPlanParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainMealPlan(dbPlan)
+}
+
+func (r *mealPlanRepository) List(ctx context.Context, userID uuid.UUID, limit int) ([]domain.MealPlan, error) {
+	dbPlans, err := r.queries.ListMealPlans(ctx, ListMealPlansParams{
+		UserID:   userID,
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]domain.MealPlan, 0, len(dbPlans))
+	for _, dbPlan := range dbPlans {
+		plan, err := toDomainMealPlan(dbPlan)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, *plan)
+	}
+
+	return plans, nil
+}
+
+func (r *mealPlanRepository) UpdateDays(ctx context.Context, plan domain.MealPlan) (*domain.MealPlan, error) {
+	days, err := marshalPlanDays(plan.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPlan, err := r.queries.UpdateMealPlanDays(ctx, UpdateMealPlanDaysParams{
+		Days:   days,
+		ID:     plan.ID,
+		UserID: plan.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainMealPlan(dbPlan)
+}
+
+func marshalPlanDays(days []domain.MealPlanDay) (json.RawMessage, error) {
+	stored := make([]mealPlanDay, 0, len(days))
+	for _, day := range days {
+		storedDay := mealPlanDay{
+			Date:          day.Date.Format(time.DateOnly),
+			Meals:         make([]plannedMeal, 0, len(day.Meals)),
+			Calories:      day.Totals.Calories,
+			Protein:       day.Totals.Protein,
+			Fat:           day.Totals.Fat,
+			Carbs:         day.Totals.Carbs,
+			GeneratedAt:   day.GeneratedAt,
+			Model:         day.Model,
+			PromptVersion: day.PromptVersion,
+		}
+		for _, meal := range day.Meals {
+			allergens := make([]string, 0, len(meal.Allergens))
+			for _, allergen := range meal.Allergens {
+				allergens = append(allergens, string(allergen))
+			}
+
+			storedDay.Meals = append(storedDay.Meals, plannedMeal{
+				MealType:    string(meal.MealType),
+				Name:        meal.Name,
+				Description: meal.Description,
+				Ingredients: meal.Ingredients,
+				Allergens:   allergens,
+				Diet:        string(meal.Diet),
+				Calories:    meal.Calories,
+				Protein:     meal.Protein,
+				Fat:         meal.Fat,
+				Carbs:       meal.Carbs,
+			})
+		}
+		stored = append(stored, storedDay)
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal meal plan days: %w", err)
+	}
+
+	return data, nil
+}
+
+func toDomainMealPlan(dbPlan MealPlan) (*domain.MealPlan, error) {
+	plan := &domain.MealPlan{
+		ID:        dbPlan.ID,
+		UserID:    dbPlan.UserID,
+		StartDate: dbPlan.StartDate,
+		Targets: domain.Nutrients{
+			Calories: dbPlan.TargetCalories,
+			Protein:  dbPlan.TargetProtein,
+			Fat:      dbPlan.TargetFat,
+			Carbs:    dbPlan.TargetCarbs,
+		},
+		Notes:     dbPlan.Notes.String,
+		CreatedAt: dbPlan.CreatedAt,
+		UpdatedAt: dbPlan.UpdatedAt,
+	}
+	if err := json.Unmarshal(dbPlan.Restrictions, &plan.Restrictions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal meal plan restrictions: %w", err)
+	}
+
+	var stored []mealPlanDay
+	if err := json.Unmarshal(dbPlan.Days, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal meal plan days: %w", err)
+	}
+
+	plan.Days = make([]domain.MealPlanDay, 0, len(stored))
+	for _, storedDay := range stored {
+		date, err := time.Parse(time.DateOnly, storedDay.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse meal plan date: %w", err)
+		}
+
+		day := domain.MealPlanDay{
+			Date:  date,
+			Meals: make([]domain.PlannedMeal, 0, len(storedDay.Meals)),
+			Totals: domain.Nutrients{
+				Calories: storedDay.Calories,
+				Protein:  storedDay.Protein,
+				Fat:      storedDay.Fat,
+				Carbs:    storedDay.Carbs,
+			},
+			GeneratedAt: storedDay.GeneratedAt,
+			Provenance: domain.Provenance{
+				Model:         storedDay.Model,
+				PromptVersion: storedDay.PromptVersion,
+			},
+		}
+		for _, meal := range storedDay.Meals {
+			allergens := make([]domain.Allergen, 0, len(meal.Allergens))
+			for _, allergen := range meal.Allergens {
+				allergens = append(allergens, domain.Allergen(allergen))
+			}
+
+			day.Meals = append(day.Meals, domain.PlannedMeal{
+				MealType:    domain.MealType(meal.MealType),
+				Name:        meal.Name,
+				Description: meal.Description,
+				Ingredients: meal.Ingredients,
+				Allergens:   allergens,
+				Diet:        domain.Diet(meal.Diet),
+				Nutrients: domain.Nutrients{
+					Calories: meal.Calories,
+					Protein:  meal.Protein,
+					Fat:      meal.Fat,
+					Carbs:    meal.Carbs,
+				},
+			})
+		}
+		plan.Days = append(plan.Days, day)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type productRepository struct {
+	queries *Queries
+}
+
+func NewProductRepository(db *sql.DB) domain.ProductRepository {
+	return &productRepository{
+		queries: New(db),
+	}
+}
+
+func (r *productRepository) Get(ctx context.Context, barcode string) (*domain.Product, error) {
+	dbProduct, err := r.queries.GetProduct(ctx, barcode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &domain.Product{
+		Barcode:      dbProduct.Barcode,
+		Name:         dbProduct.Name,
+		Brand:        dbProduct.Brand.String,
+		ServingGrams: fromNullFloat64(dbProduct.ServingGrams),
+		Nutrients: domain.Nutrients{
+			Calories: dbProduct.Calories,
+			Protein:  dbProduct.Protein,
+			Fat:      dbProduct.Fat,
+			Carbs:    dbProduct.Carbs,
+		},
+		Micronutrients: domain.Micronutrients{
+			Fiber:        fromNullFloat64(dbProduct.Fiber),
+			Sugar:        fromNullFloat64(dbProduct.Sugar),
+			SaturatedFat: fromNullFloat64(dbProduct.SaturatedFat),
+			SodiumMg:     fromNullFloat64(dbProduct.SodiumMg),
+		},
+		Source: dbProduct.Source,
+	}, nil
+}
+
+func (r *productRepository) Upsert(ctx context.Context, product domain.Product) error {
+	return r.queries.UpsertProduct(ctx, UpsertProductParams{
+		Barcode:      product.Barcode,
+		Name:         product.Name,
+		Brand:        toNullString(product.Brand),
+		ServingGrams: toNullFloat64(product.ServingGrams),
+		Calories:     product.Calories,
+		Protein:      product.Protein,
+		Fat:          product.Fat,
+		Carbs:        product.Carbs,
+		Fiber:        toNullFloat64(product.Fiber),
+		Sugar:        toNullFloat64(product.Sugar),
+		SaturatedFat: toNullFloat64(product.SaturatedFat),
+		SodiumMg:     toNullFloat64(product.SodiumMg),
+		Source:       product.Source,
+	})
+}
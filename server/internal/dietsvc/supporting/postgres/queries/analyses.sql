@@ -0,0 +1,75 @@
+-- name: CreateAnalysis :one
+INSERT INTO analyses (
+    user_id,
+    kind,
+    food_name,
+    calories,
+    protein,
+    fat,
+    carbs,
+    confidence,
+    model,
+    prompt_version,
+    items,
+    cached
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetAnalysis :one
+SELECT * FROM analyses
+WHERE id = $1 AND user_id = $2;
+
+-- name: CorrectAnalysis :one
+UPDATE analyses
+SET
+    corrected_calories = sqlc.arg('corrected_calories'),
+    corrected_protein = sqlc.arg('corrected_protein'),
+    corrected_fat = sqlc.arg('corrected_fat'),
+    corrected_carbs = sqlc.arg('corrected_carbs'),
+    corrected_at = NOW()
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: AccuracyByPromptVersion :many
+SELECT
+    prompt_version,
+    COUNT(*) AS samples,
+    AVG(ABS(corrected_calories - calories))::DOUBLE PRECISION AS mae_calories,
+    AVG(ABS(corrected_protein - protein))::DOUBLE PRECISION AS mae_protein,
+    AVG(ABS(corrected_fat - fat))::DOUBLE PRECISION AS mae_fat,
+    AVG(ABS(corrected_carbs - carbs))::DOUBLE PRECISION AS mae_carbs
+FROM analyses
+WHERE corrected_at IS NOT NULL
+    AND NOT cached
+    AND created_at >= sqlc.arg('created_from')
+    AND created_at < sqlc.arg('created_to')
+GROUP BY prompt_version
+HAVING COUNT(*) >= sqlc.arg('min_samples')
+ORDER BY prompt_version;
+
+-- name: AccuracyByFood :many
+SELECT
+    item.name::TEXT AS food_name,
+    a.prompt_version,
+    COUNT(*) AS samples,
+    AVG(ABS(a.corrected_calories - a.calories) * item.calories / NULLIF(a.calories, 0))::DOUBLE PRECISION AS mae_calories,
+    AVG(ABS(a.corrected_protein - a.protein) * item.protein / NULLIF(a.protein, 0))::DOUBLE PRECISION AS mae_protein,
+    AVG(ABS(a.corrected_fat - a.fat) * item.fat / NULLIF(a.fat, 0))::DOUBLE PRECISION AS mae_fat,
+    AVG(ABS(a.corrected_carbs - a.carbs) * item.carbs / NULLIF(a.carbs, 0))::DOUBLE PRECISION AS mae_carbs
+FROM analyses a
+CROSS JOIN LATERAL jsonb_to_recordset(a.items) AS item(
+    name TEXT,
+    calories DOUBLE PRECISION,
+    protein DOUBLE PRECISION,
+    fat DOUBLE PRECISION,
+    carbs DOUBLE PRECISION
+)
+WHERE a.corrected_at IS NOT NULL
+    AND NOT a.cached
+    AND a.created_at >= sqlc.arg('created_from')
+    AND a.created_at < sqlc.arg('created_to')
+GROUP BY item.name, a.prompt_version
+HAVING COUNT(*) >= sqlc.arg('min_samples')
+ORDER BY samples DESC, food_name, prompt_version
+LIMIT sqlc.arg('row_limit');
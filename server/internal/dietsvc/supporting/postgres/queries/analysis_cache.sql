@@ -0,0 +1,19 @@
+-- name: GetCachedAnalysis :one
+SELECT * FROM analysis_cache
+WHERE cache_key = $1 AND expires_at > NOW();
+
+-- name: UpsertCachedAnalysis :exec
+INSERT INTO analysis_cache (
+    cache_key,
+    analysis,
+    expires_at
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (cache_key) DO UPDATE SET
+    analysis = EXCLUDED.analysis,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW();
+
+-- name: DeleteExpiredCachedAnalyses :exec
+DELETE FROM analysis_cache
+WHERE expires_at < NOW();
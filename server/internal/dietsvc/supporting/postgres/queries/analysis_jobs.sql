@@ -0,0 +1,60 @@
+-- name: CreateAnalysisJob :one
+INSERT INTO analysis_jobs (
+    user_id,
+    kind,
+    image,
+    description,
+    hints,
+    bypass_cache,
+    callback_url
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetAnalysisJob :one
+SELECT * FROM analysis_jobs
+WHERE id = $1 AND user_id = $2;
+
+-- name: ClaimAnalysisJob :one
+UPDATE analysis_jobs
+SET
+    status = 'running',
+    attempts = attempts + 1,
+    started_at = NOW(),
+    updated_at = NOW()
+WHERE id = (
+    SELECT id FROM analysis_jobs
+    WHERE (status = 'pending' AND available_at <= NOW())
+        OR (status = 'running' AND started_at < sqlc.arg('stale_before'))
+    ORDER BY created_at
+    FOR UPDATE SKIP LOCKED
+    LIMIT 1
+)
+RETURNING *;
+
+-- name: CompleteAnalysisJob :one
+UPDATE analysis_jobs
+SET
+    status = $1,
+    result = $2,
+    cached = $3,
+    error_code = $4,
+    error_message = $5,
+    image = NULL,
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $6 AND status = 'running' AND attempts = $7
+RETURNING *;
+
+-- name: ReleaseAnalysisJob :exec
+UPDATE analysis_jobs
+SET
+    status = 'pending',
+    started_at = NULL,
+    available_at = $1,
+    updated_at = NOW()
+WHERE id = $2 AND status = 'running' AND attempts = $3;
+
+-- name: DeleteCompletedAnalysisJobs :exec
+DELETE FROM analysis_jobs
+WHERE completed_at < sqlc.arg('completed_before');
@@ -0,0 +1,136 @@
+-- name: CreateDietEntry :one
+INSERT INTO diet_entries (
+    user_id,
+    name,
+    description,
+    calories,
+    protein,
+    fat,
+    carbs,
+    eaten_at,
+    image_ref,
+    source,
+    confidence,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg,
+    meal_type,
+    analysis_id,
+    model,
+    prompt_version
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+) RETURNING *;
+
+-- name: GetDietEntry :one
+SELECT * FROM diet_entries
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListDietEntries :many
+SELECT * FROM diet_entries
+WHERE user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('from_time')::timestamptz IS NULL OR eaten_at >= sqlc.narg('from_time'))
+    AND (sqlc.narg('to_time')::timestamptz IS NULL OR eaten_at < sqlc.narg('to_time'))
+    AND (
+        sqlc.narg('cursor_eaten_at')::timestamptz IS NULL
+        OR (eaten_at, id) < (sqlc.narg('cursor_eaten_at'), sqlc.narg('cursor_id')::uuid)
+    )
+ORDER BY eaten_at DESC, id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: UpdateDietEntry :one
+UPDATE diet_entries
+SET
+    name = sqlc.arg('name'),
+    description = sqlc.narg('description'),
+    calories = sqlc.arg('calories'),
+    protein = sqlc.arg('protein'),
+    fat = sqlc.arg('fat'),
+    carbs = sqlc.arg('carbs'),
+    eaten_at = sqlc.arg('eaten_at'),
+    image_ref = sqlc.narg('image_ref'),
+    source = sqlc.arg('source'),
+    confidence = sqlc.narg('confidence'),
+    fiber = sqlc.narg('fiber'),
+    sugar = sqlc.narg('sugar'),
+    saturated_fat = sqlc.narg('saturated_fat'),
+    sodium_mg = sqlc.narg('sodium_mg'),
+    meal_type = sqlc.narg('meal_type'),
+    analysis_id = sqlc.narg('analysis_id'),
+    model = sqlc.narg('model'),
+    prompt_version = sqlc.narg('prompt_version'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: DeleteDietEntry :execrows
+DELETE FROM diet_entries
+WHERE id = $1 AND user_id = $2;
+
+-- name: SummarizeDietEntries :many
+WITH periods AS (
+    SELECT generate_series(
+        date_trunc(sqlc.arg('granularity')::text, sqlc.arg('from_time')::timestamptz AT TIME ZONE sqlc.arg('time_zone')::text),
+        (sqlc.arg('to_time')::timestamptz AT TIME ZONE sqlc.arg('time_zone')::text) - INTERVAL '1 microsecond',
+        ('1 ' || sqlc.arg('granularity')::text)::interval
+    ) AS period_start
+),
+entries AS (
+    SELECT
+        date_trunc(sqlc.arg('granularity')::text, eaten_at AT TIME ZONE sqlc.arg('time_zone')::text) AS period_start,
+        (eaten_at AT TIME ZONE sqlc.arg('time_zone')::text)::date AS local_date,
+        calories,
+        protein,
+        fat,
+        carbs
+    FROM diet_entries
+    WHERE user_id = sqlc.arg('user_id')
+        AND eaten_at >= sqlc.arg('from_time')
+        AND eaten_at < sqlc.arg('to_time')
+)
+SELECT
+    p.period_start::date AS period_start,
+    COUNT(e.local_date) AS entry_count,
+    COUNT(DISTINCT e.local_date) AS days_logged,
+    COALESCE(SUM(e.calories), 0)::float8 AS calories,
+    COALESCE(SUM(e.protein), 0)::float8 AS protein,
+    COALESCE(SUM(e.fat), 0)::float8 AS fat,
+    COALESCE(SUM(e.carbs), 0)::float8 AS carbs,
+    COALESCE(SUM(e.calories) / NULLIF(COUNT(DISTINCT e.local_date), 0), 0)::float8 AS avg_daily_calories,
+    COALESCE(SUM(e.protein) / NULLIF(COUNT(DISTINCT e.local_date), 0), 0)::float8 AS avg_daily_protein,
+    COALESCE(SUM(e.fat) / NULLIF(COUNT(DISTINCT e.local_date), 0), 0)::float8 AS avg_daily_fat,
+    COALESCE(SUM(e.carbs) / NULLIF(COUNT(DISTINCT e.local_date), 0), 0)::float8 AS avg_daily_carbs
+FROM periods p
+LEFT JOIN entries e ON e.period_start = p.period_start
+GROUP BY p.period_start
+ORDER BY p.period_start;
+
+-- name: ListRecentFoods :many
+SELECT DISTINCT ON (LOWER(name))
+    id,
+    user_id,
+    name,
+    description,
+    calories,
+    protein,
+    fat,
+    carbs,
+    eaten_at,
+    image_ref,
+    source,
+    confidence,
+    created_at,
+    updated_at,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg,
+    meal_type,
+    analysis_id,
+    model,
+    prompt_version,
+    COUNT(*) OVER (PARTITION BY LOWER(name)) AS log_count
+FROM diet_entries
+WHERE user_id = $1 AND eaten_at >= $2
+ORDER BY LOWER(name), eaten_at DESC, id DESC;
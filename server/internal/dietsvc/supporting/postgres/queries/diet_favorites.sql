@@ -0,0 +1,45 @@
+-- name: UpsertDietFavorite :one
+INSERT INTO diet_favorites (
+    user_id,
+    name,
+    description,
+    calories,
+    protein,
+    fat,
+    carbs,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg,
+    source,
+    confidence
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) ON CONFLICT (user_id, LOWER(name)) DO UPDATE SET
+    name = EXCLUDED.name,
+    description = EXCLUDED.description,
+    calories = EXCLUDED.calories,
+    protein = EXCLUDED.protein,
+    fat = EXCLUDED.fat,
+    carbs = EXCLUDED.carbs,
+    fiber = EXCLUDED.fiber,
+    sugar = EXCLUDED.sugar,
+    saturated_fat = EXCLUDED.saturated_fat,
+    sodium_mg = EXCLUDED.sodium_mg,
+    source = EXCLUDED.source,
+    confidence = EXCLUDED.confidence,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetDietFavorite :one
+SELECT * FROM diet_favorites
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListDietFavorites :many
+SELECT * FROM diet_favorites
+WHERE user_id = $1
+ORDER BY LOWER(name), id;
+
+-- name: DeleteDietFavorite :execrows
+DELETE FROM diet_favorites
+WHERE id = $1 AND user_id = $2;
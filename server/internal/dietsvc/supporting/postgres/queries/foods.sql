@@ -0,0 +1,61 @@
+-- name: SearchFoods :many
+SELECT
+    id,
+    source,
+    source_id,
+    name,
+    category,
+    calories,
+    protein,
+    fat,
+    carbs,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg,
+    servings,
+    created_at,
+    updated_at,
+    word_similarity(sqlc.arg('query')::text, name)::float8 AS score
+FROM foods
+WHERE sqlc.arg('query')::text <% name
+ORDER BY score DESC, length(name), name
+LIMIT sqlc.arg('row_limit');
+
+-- name: SetWordSimilarityThreshold :exec
+SELECT set_config('pg_trgm.word_similarity_threshold', $1::float8::text, true);
+
+-- name: UpsertFood :exec
+INSERT INTO foods (
+    source,
+    source_id,
+    name,
+    category,
+    calories,
+    protein,
+    fat,
+    carbs,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg,
+    servings
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) ON CONFLICT (source, source_id) DO UPDATE SET
+    name = EXCLUDED.name,
+    category = EXCLUDED.category,
+    calories = EXCLUDED.calories,
+    protein = EXCLUDED.protein,
+    fat = EXCLUDED.fat,
+    carbs = EXCLUDED.carbs,
+    fiber = EXCLUDED.fiber,
+    sugar = EXCLUDED.sugar,
+    saturated_fat = EXCLUDED.saturated_fat,
+    sodium_mg = EXCLUDED.sodium_mg,
+    servings = EXCLUDED.servings,
+    updated_at = NOW();
+
+-- name: GetFood :one
+SELECT * FROM foods
+WHERE id = $1;
@@ -0,0 +1,32 @@
+-- name: CreateMealPlan :one
+INSERT INTO meal_plans (
+    user_id,
+    start_date,
+    target_calories,
+    target_protein,
+    target_fat,
+    target_carbs,
+    restrictions,
+    notes,
+    days
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetMealPlan :one
+SELECT * FROM meal_plans
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListMealPlans :many
+SELECT * FROM meal_plans
+WHERE user_id = sqlc.arg('user_id')
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: UpdateMealPlanDays :one
+UPDATE meal_plans
+SET
+    days = sqlc.arg('days'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id')
+RETURNING *;
@@ -0,0 +1,35 @@
+-- name: GetProduct :one
+SELECT * FROM products
+WHERE barcode = $1;
+
+-- name: UpsertProduct :exec
+INSERT INTO products (
+    barcode,
+    name,
+    brand,
+    serving_grams,
+    calories,
+    protein,
+    fat,
+    carbs,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg,
+    source
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) ON CONFLICT (barcode) DO UPDATE SET
+    name = EXCLUDED.name,
+    brand = EXCLUDED.brand,
+    serving_grams = EXCLUDED.serving_grams,
+    calories = EXCLUDED.calories,
+    protein = EXCLUDED.protein,
+    fat = EXCLUDED.fat,
+    carbs = EXCLUDED.carbs,
+    fiber = EXCLUDED.fiber,
+    sugar = EXCLUDED.sugar,
+    saturated_fat = EXCLUDED.saturated_fat,
+    sodium_mg = EXCLUDED.sodium_mg,
+    source = EXCLUDED.source,
+    updated_at = NOW();
@@ -0,0 +1,50 @@
+-- name: CreateRecipe :one
+INSERT INTO recipes (
+    user_id,
+    name,
+    description,
+    servings,
+    ingredients,
+    calories,
+    protein,
+    fat,
+    carbs,
+    fiber,
+    sugar,
+    saturated_fat,
+    sodium_mg
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: GetRecipe :one
+SELECT * FROM recipes
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListRecipes :many
+SELECT * FROM recipes
+WHERE user_id = $1
+ORDER BY LOWER(name), id;
+
+-- name: UpdateRecipe :one
+UPDATE recipes
+SET
+    name = sqlc.arg('name'),
+    description = sqlc.narg('description'),
+    servings = sqlc.arg('servings'),
+    ingredients = sqlc.arg('ingredients'),
+    calories = sqlc.arg('calories'),
+    protein = sqlc.arg('protein'),
+    fat = sqlc.arg('fat'),
+    carbs = sqlc.arg('carbs'),
+    fiber = sqlc.narg('fiber'),
+    sugar = sqlc.narg('sugar'),
+    saturated_fat = sqlc.narg('saturated_fat'),
+    sodium_mg = sqlc.narg('sodium_mg'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: DeleteRecipe :execrows
+DELETE FROM recipes
+WHERE id = $1 AND user_id = $2;
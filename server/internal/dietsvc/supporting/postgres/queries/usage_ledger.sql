@@ -0,0 +1,19 @@
+-- name: CreateUsageRecord :exec
+INSERT INTO usage_ledger (
+    user_id,
+    kind,
+    model,
+    prompt_tokens,
+    completion_tokens,
+    cost_usd,
+    cached
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: SumUsageSince :one
+SELECT
+    COUNT(*) FILTER (WHERE NOT cached)::BIGINT AS analyses,
+    COALESCE(SUM(cost_usd), 0)::DOUBLE PRECISION AS cost_usd
+FROM usage_ledger
+WHERE user_id = sqlc.arg('user_id') AND created_at >= sqlc.arg('since');
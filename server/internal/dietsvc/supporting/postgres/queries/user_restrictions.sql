@@ -0,0 +1,14 @@
+-- name: GetUserRestrictions :one
+SELECT * FROM user_restrictions
+WHERE user_id = $1;
+
+-- name: UpsertUserRestrictions :one
+INSERT INTO user_restrictions (
+    user_id,
+    restrictions
+) VALUES (
+    $1, $2
+) ON CONFLICT (user_id) DO UPDATE SET
+    restrictions = EXCLUDED.restrictions,
+    updated_at = NOW()
+RETURNING *;
@@ -0,0 +1,32 @@
+-- name: GetUserSettings :one
+SELECT * FROM user_settings
+WHERE user_id = $1;
+
+-- name: UpsertUserSettings :one
+INSERT INTO user_settings (
+    user_id,
+    target_calories,
+    target_protein,
+    target_fat,
+    target_carbs,
+    target_source,
+    height_cm,
+    weight_kg,
+    age,
+    sex,
+    activity_level
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) ON CONFLICT (user_id) DO UPDATE SET
+    target_calories = EXCLUDED.target_calories,
+    target_protein = EXCLUDED.target_protein,
+    target_fat = EXCLUDED.target_fat,
+    target_carbs = EXCLUDED.target_carbs,
+    target_source = EXCLUDED.target_source,
+    height_cm = EXCLUDED.height_cm,
+    weight_kg = EXCLUDED.weight_kg,
+    age = EXCLUDED.age,
+    sex = EXCLUDED.sex,
+    activity_level = EXCLUDED.activity_level,
+    updated_at = NOW()
+RETURNING *;
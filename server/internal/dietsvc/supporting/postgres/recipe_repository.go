@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type recipeRepository struct {
+	queries *Queries
+}
+
+func NewRecipeRepository(db *sql.DB) domain.RecipeRepository {
+	return &recipeRepository{
+		queries: New(db),
+	}
+}
+
+// recipeIngredient is the stored form of an ingredient; micronutrients are
+// null when unknown
+type recipeIngredient struct {
+	FoodID       uuid.UUID `json:"food_id"`
+	FoodName     string    `json:"food_name"`
+	Grams        float64   `json:"grams"`
+	Serving      string    `json:"serving,omitempty"`
+	Quantity     float64   `json:"quantity,omitempty"`
+	Calories     float64   `json:"calories"`
+	Protein      float64   `json:"protein"`
+	Fat          float64   `json:"fat"`
+	Carbs        float64   `json:"carbs"`
+	Fiber        *float64  `json:"fiber"`
+	Sugar        *float64  `json:"sugar"`
+	SaturatedFat *float64  `json:"saturated_fat"`
+	SodiumMg     *float64  `json:"sodium_mg"`
+}
+
+func (r *recipeRepository) Create(ctx context.Context, recipe domain.Recipe) (*domain.Recipe, error) {
+	ingredients, err := marshalIngredients(recipe.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRecipe, err := r.queries.CreateRecipe(ctx, CreateRecipeParams{
+		UserID:       recipe.UserID,
+		Name:         recipe.Name,
+		Description:  toNullString(recipe.Description),
+		Servings:     recipe.Servings,
+		Ingredients:  ingredients,
+		Calories:     recipe.Calories,
+		Protein:      recipe.Protein,
+		Fat:          recipe.Fat,
+		Carbs:        recipe.Carbs,
+		Fiber:        toNullFloat64(recipe.Fiber),
+		Sugar:        toNullFloat64(recipe.Sugar),
+		SaturatedFat: toNullFloat64(recipe.SaturatedFat),
+		SodiumMg:     toNullFloat64(recipe.SodiumMg),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainRecipe(dbRecipe)
+}
+
+func (r *recipeRepository) Get(ctx context.Context, userID, id uuid.UUID) (*domain.Recipe, error) {
+	dbRecipe, err := r.queries.GetRecipe(ctx, GetRecipeParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainRecipe(dbRecipe)
+}
+
+func (r *recipeRepository) List(ctx context.Context, userID uuid.UUID) ([]domain.Recipe, error) {
+	dbRecipes, err := r.queries.ListRecipes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipes := make([]domain.Recipe, 0, len(dbRecipes))
+	for _, dbRecipe := range dbRecipes {
+		recipe, err := toDomainRecipe(dbRecipe)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, *recipe)
+	}
+
+	return recipes, nil
+}
+
+func (r *recipeRepository) Update(ctx context.Context, recipe domain.Recipe) (*domain.Recipe, error) {
+	ingredients, err := marshalIngredients(recipe.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRecipe, err := r.queries.UpdateRecipe(ctx, UpdateRecipeParams{
+		Name:         recipe.Name,
+		Description:  toNullString(recipe.Description),
+		Servings:     recipe.Servings,
+		Ingredients:  ingredients,
+		Calories:     recipe.Calories,
+		Protein:      recipe.Protein,
+		Fat:          recipe.Fat,
+		Carbs:        recipe.Carbs,
+		Fiber:        toNullFloat64(recipe.Fiber),
+		Sugar:        toNullFloat64(recipe.Sugar),
+		SaturatedFat: toNullFloat64(recipe.SaturatedFat),
+		SodiumMg:     toNullFloat64(recipe.SodiumMg),
+		ID:           recipe.ID,
+		UserID:       recipe.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainRecipe(dbRecipe)
+}
+
+func (r *recipeRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	rows, err := r.queries.DeleteRecipe(ctx, DeleteRecipeParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func marshalIngredients(ingredients []domain.RecipeIngredient) (json.RawMessage, error) {
+	stored := make([]recipeIngredient, 0, len(ingredients))
+	for _, i := range ingredients {
+		stored = append(stored, recipeIngredient{
+			FoodID:       i.FoodID,
+			FoodName:     i.FoodName,
+			Grams:        i.Grams,
+			Serving:      i.Serving,
+			Quantity:     i.Quantity,
+			Calories:     i.Calories,
+			Protein:      i.Protein,
+			Fat:          i.Fat,
+			Carbs:        i.Carbs,
+			Fiber:        i.Fiber,
+			Sugar:        i.Sugar,
+			SaturatedFat: i.SaturatedFat,
+			SodiumMg:     i.SodiumMg,
+		})
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recipe ingredients: %w", err)
+	}
+
+	return data, nil
+}
+
+func toDomainRecipe(dbRecipe Recipe) (*domain.Recipe, error) {
+	var stored []recipeIngredient
+	if err := json.Unmarshal(dbRecipe.Ingredients, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recipe ingredients: %w", err)
+	}
+
+	recipe := &domain.Recipe{
+		ID:          dbRecipe.ID,
+		UserID:      dbRecipe.UserID,
+		Name:        dbRecipe.Name,
+		Description: dbRecipe.Description.String,
+		Servings:    dbRecipe.Servings,
+		Ingredients: make([]domain.RecipeIngredient, 0, len(stored)),
+		Nutrients: domain.Nutrients{
+			Calories: dbRecipe.Calories,
+			Protein:  dbRecipe.Protein,
+			Fat:      dbRecipe.Fat,
+			Carbs:    dbRecipe.Carbs,
+		},
+		Micronutrients: domain.Micronutrients{
+			Fiber:        fromNullFloat64(dbRecipe.Fiber),
+			Sugar:        fromNullFloat64(dbRecipe.Sugar),
+			SaturatedFat: fromNullFloat64(dbRecipe.SaturatedFat),
+			SodiumMg:     fromNullFloat64(dbRecipe.SodiumMg),
+		},
+		CreatedAt: dbRecipe.CreatedAt,
+		UpdatedAt: dbRecipe.UpdatedAt,
+	}
+	for _, i := range stored {
+		recipe.Ingredients = append(recipe.Ingredients, domain.RecipeIngredient{
+			FoodID:   i.FoodID,
+			FoodName: i.FoodName,
+			Grams:    i.Grams,
+			Serving:  i.Serving,
+			Quantity: i.Quantity,
+			Nutrients: domain.Nutrients{
+				Calories: i.Calories,
+				Protein:  i.Protein,
+				Fat:      i.Fat,
+				Carbs:    i.Carbs,
+			},
+			Micronutrients: domain.Micronutrients{
+				Fiber:        i.Fiber,
+				Sugar:        i.Sugar,
+				SaturatedFat: i.SaturatedFat,
+				SodiumMg:     i.SodiumMg,
+			},
+		})
+	}
+
+	return recipe, nil
+}
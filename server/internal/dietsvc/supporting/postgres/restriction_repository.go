@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type restrictionRepository struct {
+	queries *Queries
+}
+
+func NewRestrictionRepository(db *sql.DB) domain.RestrictionRepository {
+	return &restrictionRepository{
+		queries: New(db),
+	}
+}
+
+func (r *restrictionRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.UserRestrictions, error) {
+	dbRestrictions, err := r.queries.GetUserRestrictions(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainRestrictions(dbRestrictions)
+}
+
+func (r *restrictionRepository) Upsert(ctx context.Context, restrictions domain.UserRestrictions) (*domain.UserRestrictions, error) {
+	restrictionsJSON, err := json.Marshal(restrictions.Restrictions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal restrictions: %w", err)
+	}
+
+	dbRestrictions, err := r.queries.UpsertUserRestrictions(ctx, UpsertUserRestrictionsParams{
+		UserID:       restrictions.UserID,
+		Restrictions: restrictionsJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainRestrictions(dbRestrictions)
+}
+
+func toDomainRestrictions(dbRestrictions UserRestriction) (*domain.UserRestrictions, error) {
+	restrictions := &domain.UserRestrictions{
+		UserID:    dbRestrictions.UserID,
+		UpdatedAt: dbRestrictions.UpdatedAt,
+	}
+	if err := json.Unmarshal(dbRestrictions.Restrictions, &restrictions.Restrictions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal restrictions: %w", err)
+	}
+
+	return restrictions, nil
+}
@@ -0,0 +1,237 @@
+-- Analysis cache table (diet analyses keyed by normalized image hash)
+CREATE TABLE IF NOT EXISTS analysis_cache (
+    cache_key TEXT PRIMARY KEY,
+    analysis JSONB NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_analysis_cache_expires_at ON analysis_cache(expires_at);
+
+-- Diet entries table (meals logged by users)
+CREATE TABLE IF NOT EXISTS diet_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    calories DOUBLE PRECISION NOT NULL DEFAULT 0,
+    protein DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fat DOUBLE PRECISION NOT NULL DEFAULT 0,
+    carbs DOUBLE PRECISION NOT NULL DEFAULT 0,
+    eaten_at TIMESTAMPTZ NOT NULL,
+    image_ref TEXT,
+    source TEXT NOT NULL DEFAULT 'manual',
+    confidence DOUBLE PRECISION,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    meal_type TEXT,
+    analysis_id UUID REFERENCES analyses(id) ON DELETE SET NULL,
+    model TEXT,
+    prompt_version TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_diet_entries_user_eaten_at ON diet_entries(user_id, eaten_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_diet_entries_analysis_id ON diet_entries(analysis_id) WHERE analysis_id IS NOT NULL;
+
+-- User settings table (daily nutrition targets)
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    target_calories DOUBLE PRECISION NOT NULL,
+    target_protein DOUBLE PRECISION NOT NULL,
+    target_fat DOUBLE PRECISION NOT NULL,
+    target_carbs DOUBLE PRECISION NOT NULL,
+    target_source TEXT NOT NULL DEFAULT 'manual',
+    height_cm DOUBLE PRECISION,
+    weight_kg DOUBLE PRECISION,
+    age INTEGER,
+    sex TEXT,
+    activity_level TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Foods table (reference food composition data, nutrients per 100 g)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS foods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    category TEXT,
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    servings JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (source, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_foods_name_trgm ON foods USING GIN (name gin_trgm_ops);
+
+-- Products table (packaged foods by barcode, nutrients per 100 g)
+CREATE TABLE IF NOT EXISTS products (
+    barcode TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    brand TEXT,
+    serving_grams DOUBLE PRECISION,
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    source TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Analysis jobs table (asynchronous analyses run by background workers)
+CREATE TABLE IF NOT EXISTS analysis_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    image BYTEA,
+    description TEXT,
+    hints JSONB NOT NULL DEFAULT '{}',
+    bypass_cache BOOLEAN NOT NULL DEFAULT FALSE,
+    callback_url TEXT,
+    result JSONB NOT NULL DEFAULT 'null',
+    cached BOOLEAN NOT NULL DEFAULT FALSE,
+    error_code TEXT,
+    error_message TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    available_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_queue ON analysis_jobs(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_completed_at ON analysis_jobs(completed_at);
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_user_id ON analysis_jobs(user_id);
+
+-- Usage ledger table (model, tokens and estimated cost per analysis)
+CREATE TABLE IF NOT EXISTS usage_ledger (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    model TEXT NOT NULL,
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    completion_tokens BIGINT NOT NULL DEFAULT 0,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cached BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_ledger_user_created_at ON usage_ledger(user_id, created_at);
+
+-- Analyses table (served analyses with provenance and user corrections)
+CREATE TABLE IF NOT EXISTS analyses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    food_name TEXT NOT NULL,
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL,
+    model TEXT NOT NULL,
+    prompt_version TEXT NOT NULL,
+    corrected_calories DOUBLE PRECISION,
+    corrected_protein DOUBLE PRECISION,
+    corrected_fat DOUBLE PRECISION,
+    corrected_carbs DOUBLE PRECISION,
+    corrected_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    items JSONB NOT NULL,
+    cached BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_analyses_user_id ON analyses(user_id);
+CREATE INDEX IF NOT EXISTS idx_analyses_corrected ON analyses(created_at) WHERE corrected_at IS NOT NULL;
+
+-- User restrictions table (declared diets and allergies)
+CREATE TABLE IF NOT EXISTS user_restrictions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    restrictions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Recipes table (user dishes built from reference foods, whole-recipe nutrients)
+CREATE TABLE IF NOT EXISTS recipes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    servings DOUBLE PRECISION NOT NULL,
+    ingredients JSONB NOT NULL DEFAULT '[]',
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipes_user_id ON recipes(user_id);
+
+-- Diet favorites table (saved foods for relogging, one per name per user)
+CREATE TABLE IF NOT EXISTS diet_favorites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    source TEXT NOT NULL,
+    confidence DOUBLE PRECISION,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_diet_favorites_user_name ON diet_favorites(user_id, LOWER(name));
+
+-- Meal plans table (generated plans with the targets they were made for)
+CREATE TABLE IF NOT EXISTS meal_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    target_calories DOUBLE PRECISION NOT NULL,
+    target_protein DOUBLE PRECISION NOT NULL,
+    target_fat DOUBLE PRECISION NOT NULL,
+    target_carbs DOUBLE PRECISION NOT NULL,
+    restrictions JSONB NOT NULL DEFAULT '[]',
+    notes TEXT,
+    days JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_meal_plans_user_created_at ON meal_plans(user_id, created_at DESC);
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type settingsRepository struct {
+	queries *Queries
+}
+
+func NewSettingsRepository(db *sql.DB) domain.SettingsRepository {
+	return &settingsRepository{
+		queries: New(db),
+	}
+}
+
+func (r *settingsRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	dbSettings, err := r.queries.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainSettings(dbSettings), nil
+}
+
+func (r *settingsRepository) Upsert(ctx context.Context, settings domain.UserSettings) (*domain.UserSettings, error) {
+	params := UpsertUserSettingsParams{
+		UserID:         settings.UserID,
+		TargetCalories: settings.Targets.Calories,
+		TargetProtein:  settings.Targets.Protein,
+		TargetFat:      settings.Targets.Fat,
+		TargetCarbs:    settings.Targets.Carbs,
+		TargetSource:   string(settings.TargetSource),
+	}
+	if p := settings.Profile; p != nil {
+		params.HeightCm = sql.NullFloat64{Float64: p.HeightCm, Valid: true}
+		params.WeightKg = sql.NullFloat64{Float64: p.WeightKg, Valid: true}
+		params.Age = sql.NullInt32{Int32: int32(p.Age), Valid: true}
+		params.Sex = sql.NullString{String: string(p.Sex), Valid: true}
+		params.ActivityLevel = sql.NullString{String: string(p.ActivityLevel), Valid: true}
+	}
+
+	dbSettings, err := r.queries.UpsertUserSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainSettings(dbSettings), nil
+}
+
+func toDomainSettings(dbSettings UserSetting) *domain.UserSettings {
+	settings := &domain.UserSettings{
+		UserID: dbSettings.UserID,
+		Targets: domain.Nutrients{
+			Calories: dbSettings.TargetCalories,
+			Protein:  dbSettings.TargetProtein,
+			Fat:      dbSettings.TargetFat,
+			Carbs:    dbSettings.TargetCarbs,
+		},
+		TargetSource: domain.TargetSource(dbSettings.TargetSource),
+		CreatedAt:    dbSettings.CreatedAt,
+		UpdatedAt:    dbSettings.UpdatedAt,
+	}
+
+	// The profile columns are written together, so weight marks a saved profile
+	if dbSettings.WeightKg.Valid {
+		settings.Profile = &domain.BodyProfile{
+			HeightCm:      dbSettings.HeightCm.Float64,
+			WeightKg:      dbSettings.WeightKg.Float64,
+			Age:           int(dbSettings.Age.Int32),
+			Sex:           domain.Sex(dbSettings.Sex.String),
+			ActivityLevel: domain.ActivityLevel(dbSettings.ActivityLevel.String),
+		}
+	}
+
+	return settings
+}
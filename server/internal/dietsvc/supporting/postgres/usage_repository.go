@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+type usageRepository struct {
+	queries *Queries
+}
+
+func NewUsageRepository(db *sql.DB) domain.UsageRepository {
+	return &usageRepository{
+		queries: New(db),
+	}
+}
+
+func (r *usageRepository) Record(ctx context.Context, record domain.UsageRecord) error {
+	return r.queries.CreateUsageRecord(ctx, CreateUsageRecordParams{
+		UserID:           record.UserID,
+		Kind:             string(record.Kind),
+		Model:            record.Model,
+		PromptTokens:     record.PromptTokens,
+		CompletionTokens: record.CompletionTokens,
+		CostUsd:          record.CostUSD,
+		Cached:           record.Cached,
+	})
+}
+
+func (r *usageRepository) TotalsSince(ctx context.Context, userID uuid.UUID, since time.Time) (domain.UsageTotals, error) {
+	row, err := r.queries.SumUsageSince(ctx, SumUsageSinceParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return domain.UsageTotals{}, err
+	}
+
+	return domain.UsageTotals{
+		Analyses: int(row.Analyses),
+		CostUSD:  row.CostUsd,
+	}, nil
+}
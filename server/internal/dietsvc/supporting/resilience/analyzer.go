@@ -0,0 +1,173 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// errOutOfTime is returned when the caller's context ends, or its deadline
+// leaves no time for another attempt, before the provider answered
+var errOutOfTime = domain.ServiceUnavailable("the analysis provider did not respond in time")
+
+type Config struct {
+	// AttemptTimeout bounds each provider call
+	AttemptTimeout time.Duration
+	// MaxAttempts includes the first call
+	MaxAttempts int
+	// BaseBackoff doubles after each failed attempt, up to MaxBackoff, with
+	// jitter
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// BreakerThreshold consecutive transient failures open the breaker for
+	// BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// Retryable reports whether an error is transient, such as a 429 or 5xx
+	// response. Attempt timeouts are always retried.
+	Retryable func(error) bool
+}
+
+// Analyzer wraps a FoodAnalyzer with per-attempt timeouts, retries with
+// exponential backoff and a circuit breaker. Only transient errors are
+// retried or count against the breaker; rejected analyses are returned as is.
+type Analyzer struct {
+	next    domain.FoodAnalyzer
+	cfg     Config
+	breaker *breaker
+}
+
+var _ domain.FoodAnalyzer = (*Analyzer)(nil)
+
+func NewAnalyzer(next domain.FoodAnalyzer, cfg Config) *Analyzer {
+	return &Analyzer{
+		next:    next,
+		cfg:     cfg,
+		breaker: newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+func (a *Analyzer) AnalyzeFood(ctx context.Context, imageData []byte, mimeType string, hints domain.AnalysisHints) (*domain.DietAnalysis, error) {
+	analysis, spent, err := call(ctx, a.cfg, a.breaker, func(ctx context.Context) (*domain.DietAnalysis, error) {
+		return a.next.AnalyzeFood(ctx, imageData, mimeType, hints)
+	})
+	if err != nil {
+		return nil, err
+	}
+	spent.Add(analysis.Usage)
+	analysis.Usage = spent
+
+	return analysis, nil
+}
+
+func (a *Analyzer) AnalyzeFoodText(ctx context.Context, description string) (*domain.DietAnalysis, error) {
+	analysis, spent, err := call(ctx, a.cfg, a.breaker, func(ctx context.Context) (*domain.DietAnalysis, error) {
+		return a.next.AnalyzeFoodText(ctx, description)
+	})
+	if err != nil {
+		return nil, err
+	}
+	spent.Add(analysis.Usage)
+	analysis.Usage = spent
+
+	return analysis, nil
+}
+
+func (a *Analyzer) Provenance() domain.Provenance {
+	return a.next.Provenance()
+}
+
+// Budget is the longest a call can take: every attempt timing out with the
+// longest backoff between them
+func (a *Analyzer) Budget() time.Duration {
+	return a.cfg.budget()
+}
+
+// BreakerStatus returns the current circuit breaker state
+func (a *Analyzer) BreakerStatus() BreakerStatus {
+	return a.breaker.status()
+}
+
+// call runs fn with the attempt timeout, retrying transient failures with
+// backoff while the breaker allows it and a full attempt still fits before
+// ctx's deadline. spent is the usage of the failed attempts; it is also
+// attached to a returned error.
+func call[T any](ctx context.Context, cfg Config, b *breaker, fn func(context.Context) (T, error)) (T, domain.TokenUsage, error) {
+	var zero T
+	var spent domain.TokenUsage
+
+	backoff := cfg.BaseBackoff
+	for attempt := 1; ; attempt++ {
+		if !b.allow() {
+			return zero, spent, domain.WithUsage(domain.ServiceUnavailable("the analysis provider is failing, try again shortly"), spent)
+		}
+
+		result, err := callAttempt(ctx, cfg.AttemptTimeout, fn)
+		if err == nil {
+			b.success()
+			return result, spent, nil
+		}
+		spent.Add(domain.UsageOf(err))
+
+		switch {
+		case ctx.Err() != nil:
+			b.release()
+			return zero, spent, domain.WithUsage(errOutOfTime, spent)
+		case !cfg.isTransient(err):
+			// The provider answered; the result itself was rejected
+			b.success()
+			return zero, spent, domain.WithUsage(err, spent)
+		}
+
+		b.failure()
+		slog.Warn("analysis provider call failed", "attempt", attempt, "error", err)
+
+		if attempt >= cfg.MaxAttempts {
+			slog.Error("analysis provider call failed after retries", "attempts", attempt, "error", err)
+			return zero, spent, domain.WithUsage(domain.ServiceUnavailable("the analysis provider did not respond successfully"), spent)
+		}
+
+		// Full jitter keeps concurrent retries from arriving together
+		wait := time.Duration(rand.Int64N(int64(backoff) + 1))
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait+cfg.AttemptTimeout {
+			slog.Error("analysis provider call failed with no time left to retry", "attempts", attempt, "error", err)
+			return zero, spent, domain.WithUsage(errOutOfTime, spent)
+		}
+		select {
+		case <-ctx.Done():
+			return zero, spent, domain.WithUsage(errOutOfTime, spent)
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, cfg.MaxBackoff)
+	}
+}
+
+func callAttempt[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(attemptCtx)
+}
+
+func (cfg Config) budget() time.Duration {
+	budget := time.Duration(max(cfg.MaxAttempts, 1)) * cfg.AttemptTimeout
+	backoff := cfg.BaseBackoff
+	for range cfg.MaxAttempts - 1 {
+		budget += backoff
+		backoff = min(backoff*2, cfg.MaxBackoff)
+	}
+
+	return budget
+}
+
+func (cfg Config) isTransient(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	return cfg.Retryable != nil && cfg.Retryable(err)
+}
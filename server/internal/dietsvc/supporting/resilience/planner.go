@@ -0,0 +1,49 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// Planner wraps a MealPlanner with the retries of an Analyzer for the same
+// provider. It shares the analyzer's circuit breaker, so either one failing
+// stops calls from both.
+type Planner struct {
+	next    domain.MealPlanner
+	cfg     Config
+	breaker *breaker
+}
+
+var _ domain.MealPlanner = (*Planner)(nil)
+
+// NewPlanner wraps next like analyzer, but with its own attempt timeout since
+// a plan takes longer to generate than an analysis
+func NewPlanner(next domain.MealPlanner, analyzer *Analyzer, attemptTimeout time.Duration) *Planner {
+	cfg := analyzer.cfg
+	cfg.AttemptTimeout = attemptTimeout
+
+	return &Planner{
+		next:    next,
+		cfg:     cfg,
+		breaker: analyzer.breaker,
+	}
+}
+
+func (p *Planner) PlanMeals(ctx context.Context, req domain.MealPlanRequest) (*domain.GeneratedPlan, error) {
+	plan, spent, err := call(ctx, p.cfg, p.breaker, func(ctx context.Context) (*domain.GeneratedPlan, error) {
+		return p.next.PlanMeals(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	spent.Add(plan.Usage)
+	plan.Usage = spent
+
+	return plan, nil
+}
+
+func (p *Planner) Provenance() domain.Provenance {
+	return p.next.Provenance()
+}
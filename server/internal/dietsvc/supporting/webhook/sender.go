@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed with
+// the configured secret and prefixed with "sha256="
+const SignatureHeader = "X-Balancewise-Signature"
+
+const requestTimeout = 10 * time.Second
+
+type Config struct {
+	// Secret signs callback bodies; callbacks are unsigned when it is empty
+	Secret string
+	// AllowPrivate permits callbacks to loopback and private network
+	// addresses, for local development only
+	AllowPrivate bool
+}
+
+// Sender posts job events to client callback URLs
+type Sender struct {
+	client *http.Client
+	secret []byte
+}
+
+var _ domain.JobNotifier = (*Sender)(nil)
+
+func NewSender(cfg Config) *Sender {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !cfg.AllowPrivate {
+		// Check the resolved address at connect time so DNS cannot be used to
+		// reach internal services
+		dialer.Control = rejectPrivate
+	}
+
+	return &Sender{
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			// Redirects could point back at an internal address via a
+			// different host, so they are not followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		secret: []byte(cfg.Secret),
+	}
+}
+
+type payload struct {
+	JobID       string `json:"job_id"`
+	Status      string `json:"status"`
+	CompletedAt string `json:"completed_at"`
+}
+
+func (s *Sender) Notify(ctx context.Context, callbackURL string, event domain.JobEvent) error {
+	body, err := json.Marshal(payload{
+		JobID:       event.JobID.String(),
+		Status:      string(event.Status),
+		CompletedAt: event.CompletedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("callback request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func rejectPrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("callback address %s is not allowed", host)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package dietsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/priyanshujain/balancewise/server/internal/dietsvc/domain"
+)
+
+// Quota is the number of uncached analyses a user may run per UTC day and
+// calendar month. A zero limit is unlimited.
+type Quota struct {
+	Daily   int
+	Monthly int
+}
+
+// QuotaUsage is a user's usage in the current quota period. Limit and
+// Remaining are nil when the period is unlimited.
+type QuotaUsage struct {
+	Limit     *int
+	Used      int
+	Remaining *int
+	CostUSD   float64
+	ResetsAt  time.Time
+}
+
+type UsageReport struct {
+	Daily   QuotaUsage
+	Monthly QuotaUsage
+}
+
+func (s *Service) GetUsage(ctx context.Context, userID uuid.UUID) (*UsageReport, error) {
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	daily, err := s.usageRepo.TotalsSince(ctx, userID, dayStart)
+	if err != nil {
+		return nil, domain.WrapError("failed to get daily usage", err)
+	}
+
+	monthly, err := s.usageRepo.TotalsSince(ctx, userID, monthStart)
+	if err != nil {
+		return nil, domain.WrapError("failed to get monthly usage", err)
+	}
+
+	return &UsageReport{
+		Daily:   newQuotaUsage(s.quota.Daily, daily, dayStart.AddDate(0, 0, 1)),
+		Monthly: newQuotaUsage(s.quota.Monthly, monthly, monthStart.AddDate(0, 1, 0)),
+	}, nil
+}
+
+func newQuotaUsage(limit int, totals domain.UsageTotals, resetsAt time.Time) QuotaUsage {
+	usage := QuotaUsage{
+		Used:     totals.Analyses,
+		CostUSD:  totals.CostUSD,
+		ResetsAt: resetsAt,
+	}
+	if limit > 0 {
+		remaining := max(limit-totals.Analyses, 0)
+		usage.Limit, usage.Remaining = &limit, &remaining
+	}
+
+	return usage
+}
+
+// checkQuota returns a QuotaExceeded error when the user has no analyses
+// left today or this month. Concurrent requests may overshoot a limit by
+// the number running at once.
+func (s *Service) checkQuota(ctx context.Context, userID uuid.UUID) error {
+	if s.quota.Daily <= 0 && s.quota.Monthly <= 0 {
+		return nil
+	}
+
+	report, err := s.GetUsage(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if report.Daily.Remaining != nil && *report.Daily.Remaining == 0 {
+		return domain.QuotaExceeded(fmt.Sprintf("daily limit of %d analyses reached", s.quota.Daily))
+	}
+	if report.Monthly.Remaining != nil && *report.Monthly.Remaining == 0 {
+		return domain.QuotaExceeded(fmt.Sprintf("monthly limit of %d analyses reached", s.quota.Monthly))
+	}
+
+	return nil
+}
+
+// recordUsage adds an analysis to the user's ledger. A failure is logged
+// rather than failing an analysis that has already been paid for.
+func (s *Service) recordUsage(ctx context.Context, userID uuid.UUID, kind domain.UsageKind, cached bool, usage domain.TokenUsage) {
+	err := s.usageRepo.Record(ctx, domain.UsageRecord{
+		UserID:     userID,
+		Kind:       kind,
+		Cached:     cached,
+		TokenUsage: usage,
+	})
+	if err != nil {
+		slog.Error("failed to record analysis usage", "user_id", userID, "error", err)
+	}
+}
+
+// recordFailedUsage records what a failed analysis cost when the provider
+// charged for any of it. It outlives ctx, which may be why the analysis
+// failed.
+func (s *Service) recordFailedUsage(ctx context.Context, userID uuid.UUID, kind domain.UsageKind, err error) {
+	if usage := domain.UsageOf(err); !usage.IsZero() {
+		s.recordUsage(context.WithoutCancel(ctx), userID, kind, false, usage)
+	}
+}
@@ -26,6 +26,11 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func Middleware(enabled bool) func(http.Handler) http.Handler {
 	if !enabled {
 		return func(h http.Handler) http.Handler { return h }
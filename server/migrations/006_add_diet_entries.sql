@@ -0,0 +1,21 @@
+-- Migration: Add diet_entries table
+-- Description: Stores users' logged meals on the server
+
+CREATE TABLE IF NOT EXISTS diet_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    calories DOUBLE PRECISION NOT NULL DEFAULT 0,
+    protein DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fat DOUBLE PRECISION NOT NULL DEFAULT 0,
+    carbs DOUBLE PRECISION NOT NULL DEFAULT 0,
+    eaten_at TIMESTAMPTZ NOT NULL,
+    image_ref TEXT, -- app image URI or Google Drive file ID
+    source TEXT NOT NULL DEFAULT 'manual', -- manual, image or text analysis
+    confidence DOUBLE PRECISION, -- analysis confidence, NULL for manual entries
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_diet_entries_user_eaten_at ON diet_entries(user_id, eaten_at DESC, id DESC);
@@ -0,0 +1,18 @@
+-- Migration: Add user_settings table
+-- Description: Stores users' daily nutrition targets and the body profile used to calculate them
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    target_calories DOUBLE PRECISION NOT NULL,
+    target_protein DOUBLE PRECISION NOT NULL,
+    target_fat DOUBLE PRECISION NOT NULL,
+    target_carbs DOUBLE PRECISION NOT NULL,
+    target_source TEXT NOT NULL DEFAULT 'manual', -- manual or calculated from the profile
+    height_cm DOUBLE PRECISION,
+    weight_kg DOUBLE PRECISION,
+    age INTEGER,
+    sex TEXT, -- male or female
+    activity_level TEXT, -- sedentary, light, moderate, active or very_active
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
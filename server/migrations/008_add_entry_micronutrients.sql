@@ -0,0 +1,7 @@
+-- Migration: Add micronutrient columns to diet_entries
+-- Description: Optional fiber, sugar, saturated fat (grams) and sodium (milligrams); NULL when unknown
+
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS fiber DOUBLE PRECISION;
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS sugar DOUBLE PRECISION;
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS saturated_fat DOUBLE PRECISION;
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS sodium_mg DOUBLE PRECISION;
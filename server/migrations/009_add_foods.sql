@@ -0,0 +1,26 @@
+-- Migration: Add foods table
+-- Description: Reference food composition data with per-100 g nutrients, searchable by trigram similarity
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS foods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source TEXT NOT NULL, -- dataset the row was imported from
+    source_id TEXT NOT NULL, -- identifier within the source dataset
+    name TEXT NOT NULL,
+    category TEXT,
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    servings JSONB NOT NULL DEFAULT '[]', -- [{"label": "1 cup", "grams": 158}]
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (source, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_foods_name_trgm ON foods USING GIN (name gin_trgm_ops);
@@ -0,0 +1,20 @@
+-- Migration: Add products table
+-- Description: Packaged food catalog keyed by normalized GTIN-13/EAN-8 barcode, with per-100 g nutrients
+
+CREATE TABLE IF NOT EXISTS products (
+    barcode TEXT PRIMARY KEY, -- EAN-13 (UPC-A padded with a leading zero) or EAN-8
+    name TEXT NOT NULL,
+    brand TEXT,
+    serving_grams DOUBLE PRECISION, -- NULL when the label has no serving size
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION,
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    source TEXT NOT NULL, -- catalog the row was imported from, e.g. openfoodfacts
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
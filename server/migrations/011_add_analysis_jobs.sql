@@ -0,0 +1,25 @@
+-- Migration: Add analysis_jobs table
+-- Description: Queue of asynchronous image and text analyses run by background workers
+
+CREATE TABLE IF NOT EXISTS analysis_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind TEXT NOT NULL, -- image or text
+    status TEXT NOT NULL DEFAULT 'pending', -- pending, running, succeeded or failed
+    image BYTEA, -- normalized image, cleared once the job completes
+    description TEXT,
+    hints JSONB NOT NULL DEFAULT '{}',
+    bypass_cache BOOLEAN NOT NULL DEFAULT FALSE,
+    callback_url TEXT,
+    result JSONB NOT NULL DEFAULT 'null', -- JSON null until the job succeeds
+    cached BOOLEAN NOT NULL DEFAULT FALSE,
+    error_code TEXT,
+    error_message TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_queue ON analysis_jobs(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_completed_at ON analysis_jobs(completed_at);
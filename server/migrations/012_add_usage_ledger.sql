@@ -0,0 +1,23 @@
+-- Migration: Add usage_ledger table and analysis job ownership
+-- Description: Records model, token usage and estimated cost of every analysis for per-user quotas
+
+CREATE TABLE IF NOT EXISTS usage_ledger (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL, -- image or text
+    model TEXT NOT NULL, -- model reported by the provider, empty for cache hits
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    completion_tokens BIGINT NOT NULL DEFAULT 0,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0, -- estimate from list prices
+    cached BOOLEAN NOT NULL DEFAULT FALSE, -- cache hits do not count toward quotas
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_ledger_user_created_at ON usage_ledger(user_id, created_at);
+
+-- Analysis now requires authentication, so jobs belong to a user
+ALTER TABLE analysis_jobs ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+DELETE FROM analysis_jobs WHERE user_id IS NULL;
+ALTER TABLE analysis_jobs ALTER COLUMN user_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_analysis_jobs_user_id ON analysis_jobs(user_id);
@@ -0,0 +1,25 @@
+-- Migration: Add analyses table
+-- Description: Analyses served to users with their provenance and any user correction, for accuracy reporting
+
+CREATE TABLE IF NOT EXISTS analyses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL, -- image or text
+    food_name TEXT NOT NULL,
+    calories DOUBLE PRECISION NOT NULL, -- original estimate
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL,
+    model TEXT NOT NULL,
+    prompt_version TEXT NOT NULL,
+    corrected_calories DOUBLE PRECISION, -- user correction, NULL when not corrected
+    corrected_protein DOUBLE PRECISION,
+    corrected_fat DOUBLE PRECISION,
+    corrected_carbs DOUBLE PRECISION,
+    corrected_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_analyses_user_id ON analyses(user_id);
+CREATE INDEX IF NOT EXISTS idx_analyses_corrected ON analyses(created_at) WHERE corrected_at IS NOT NULL;
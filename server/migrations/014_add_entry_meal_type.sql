@@ -0,0 +1,4 @@
+-- Migration: Add meal type to diet_entries
+-- Description: breakfast, lunch, dinner or snack; NULL for entries logged before meal types were recorded
+
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS meal_type TEXT;
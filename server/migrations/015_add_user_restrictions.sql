@@ -0,0 +1,9 @@
+-- Migration: Add user_restrictions table
+-- Description: Diets and allergies a user declared, checked against analyzed food
+
+CREATE TABLE IF NOT EXISTS user_restrictions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    restrictions JSONB NOT NULL DEFAULT '[]', -- restriction names such as vegetarian or nut_allergy
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
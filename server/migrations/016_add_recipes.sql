@@ -0,0 +1,23 @@
+-- Migration: Add recipes table
+-- Description: User recipes built from reference foods, with nutrients computed for the whole recipe
+
+CREATE TABLE IF NOT EXISTS recipes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    servings DOUBLE PRECISION NOT NULL, -- servings the whole recipe makes
+    ingredients JSONB NOT NULL DEFAULT '[]', -- food, amount and nutrients of each ingredient
+    calories DOUBLE PRECISION NOT NULL, -- whole recipe
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION, -- NULL unless every ingredient reports it
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipes_user_id ON recipes(user_id);
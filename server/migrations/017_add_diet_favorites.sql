@@ -0,0 +1,24 @@
+-- Migration: Add diet favorites table
+-- Description: Foods saved by users for relogging without another analysis
+
+CREATE TABLE IF NOT EXISTS diet_favorites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    calories DOUBLE PRECISION NOT NULL, -- copied from the favorited entry
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    fiber DOUBLE PRECISION, -- NULL when unknown
+    sugar DOUBLE PRECISION,
+    saturated_fat DOUBLE PRECISION,
+    sodium_mg DOUBLE PRECISION,
+    source TEXT NOT NULL, -- source of the favorited entry
+    confidence DOUBLE PRECISION,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Favoriting a name again replaces the saved values
+CREATE UNIQUE INDEX IF NOT EXISTS idx_diet_favorites_user_name ON diet_favorites(user_id, LOWER(name));
@@ -0,0 +1,19 @@
+-- Migration: Add meal plans table
+-- Description: Generated weekly meal plans, regenerable per day
+
+CREATE TABLE IF NOT EXISTS meal_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL, -- first planned local date
+    target_calories DOUBLE PRECISION NOT NULL, -- daily targets the plan was generated for
+    target_protein DOUBLE PRECISION NOT NULL,
+    target_fat DOUBLE PRECISION NOT NULL,
+    target_carbs DOUBLE PRECISION NOT NULL,
+    restrictions JSONB NOT NULL DEFAULT '[]', -- restrictions the plan was generated for
+    notes TEXT, -- user preferences passed to the planner
+    days JSONB NOT NULL DEFAULT '[]', -- meals, totals and provenance of each day
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_meal_plans_user_created_at ON meal_plans(user_id, created_at DESC);
@@ -0,0 +1,8 @@
+-- Migration: Add analysis provenance to diet_entries
+-- Description: Links entries to the analysis their values came from; NULL for manual entries and entries logged before provenance was recorded
+
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS analysis_id UUID REFERENCES analyses(id) ON DELETE SET NULL; -- analysis the values came from
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS model TEXT; -- model of that analysis
+ALTER TABLE diet_entries ADD COLUMN IF NOT EXISTS prompt_version TEXT; -- prompt version of that analysis
+
+CREATE INDEX IF NOT EXISTS idx_diet_entries_analysis_id ON diet_entries(analysis_id) WHERE analysis_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Migration: Add available_at to analysis_jobs
+-- Description: Jobs that hit a provider error wait in the queue until available_at instead of holding a worker
+
+ALTER TABLE analysis_jobs ADD COLUMN IF NOT EXISTS available_at TIMESTAMPTZ NOT NULL DEFAULT NOW(); -- earliest time a pending job may be claimed
@@ -0,0 +1,17 @@
+-- Migration: Add per-item estimates and cache hits to analyses
+-- Description: Accuracy is reported per food item, leaving out analyses served again from the cache
+
+ALTER TABLE analyses ADD COLUMN IF NOT EXISTS items JSONB; -- per-item estimates with normalized names
+-- Analyses stored before items were kept count as one item named after the meal
+UPDATE analyses
+SET items = jsonb_build_array(jsonb_build_object(
+    'name', regexp_replace(LOWER(TRIM(food_name)), '\s+', ' ', 'g'),
+    'calories', calories,
+    'protein', protein,
+    'fat', fat,
+    'carbs', carbs
+))
+WHERE items IS NULL;
+ALTER TABLE analyses ALTER COLUMN items SET NOT NULL;
+
+ALTER TABLE analyses ADD COLUMN IF NOT EXISTS cached BOOLEAN NOT NULL DEFAULT FALSE; -- served from the analysis cache, a repeat of an earlier estimate